@@ -0,0 +1,32 @@
+package rustemmer
+
+// Algorithm selects a preset of the options that affect how words are prepared for stemming.
+// The Porter steps themselves follow the Snowball specification in every mode.
+type Algorithm int
+
+const (
+	// AlgorithmLegacy stems words as they are written, keeping their case and the letter "ё",
+	// and leaves words whose bases would be shorter than DefaultMinStemLength unstemmed. It is the default.
+	AlgorithmLegacy Algorithm = iota
+	// AlgorithmSnowball prepares words as the Snowball reference implementation does: they are
+	// converted to lower case and "ё" is replaced with "е", and the minimal stem length is not checked,
+	// so the bases match the published output.
+	AlgorithmSnowball
+)
+
+// WithAlgorithm sets the case handling, the "ё" normalization and the minimal stem length of the algorithm.
+// Options given after it override the corresponding settings.
+func WithAlgorithm(algorithm Algorithm) Option {
+	return func(r *RuStemmer) {
+		switch algorithm {
+		case AlgorithmSnowball:
+			r.caseHandling = CaseLower
+			r.yoNormalization = true
+			r.minStemLength = 0
+		default:
+			r.caseHandling = CaseOriginal
+			r.yoNormalization = false
+			r.minStemLength = DefaultMinStemLength
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package rustemmer
+
+import (
+	"testing"
+)
+
+func TestWithAlgorithm(t *testing.T) {
+	testWords := []struct {
+		opts     []Option
+		word     string
+		expected string
+	}{
+		{nil, "Берёзами", "Берёз"},
+		{[]Option{WithAlgorithm(AlgorithmLegacy)}, "Берёзами", "Берёз"},
+		{[]Option{WithAlgorithm(AlgorithmSnowball)}, "Берёзами", "берез"},
+		{[]Option{WithAlgorithm(AlgorithmSnowball)}, "ВАГОНЫ", "вагон"},
+		{[]Option{WithAlgorithm(AlgorithmSnowball), WithCaseHandling(CasePreserve)}, "Берёзами", "Берез"},
+		{[]Option{WithYoNormalization(), WithAlgorithm(AlgorithmLegacy)}, "берёзами", "берёз"},
+		{nil, "ей", "ей"},
+		{[]Option{WithAlgorithm(AlgorithmSnowball)}, "ей", "е"},
+	}
+
+	for _, test := range testWords {
+		if base := New(test.opts...).GetWordBase(test.word); base != test.expected {
+			t.Errorf("Not equal: %s != %s", test.expected, base)
+		}
+	}
+}
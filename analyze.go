@@ -0,0 +1,72 @@
+package rustemmer
+
+// Coarse grammatical categories reported by Analyze.
+const (
+	CategoryNone       = ""
+	CategoryGerund     = "gerund"
+	CategoryReflexive  = "reflexive"
+	CategoryAdjective  = "adjective"
+	CategoryParticiple = "participle"
+	CategoryVerb       = "verb"
+	CategoryNoun       = "noun"
+)
+
+// Analyze returns the base of the word together with the removed suffixes and their grammatical category.
+func Analyze(word string) (stem string, suffixes []string, category string) {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.Analyze(word)
+}
+
+// Analyze returns the base of the word together with the suffixes removed from it, in order of removal,
+// and one of the Category constants as a coarse hint of the part of speech of the word.
+// The category is that of the ending removed at step 1, where participles are told apart from adjectives.
+// A word whose only ending is reflexive, such as "ся", is CategoryReflexive. If step 1 removed nothing,
+// a derivational suffix, such as "ость", makes a noun and a superlative one an adjective.
+// Prefixes removed with WithPrefixStripping are not reported. The base is that of GetWordBase, so words whose
+// bases come from the dictionary, the exceptions or the lemmas have no suffixes and are CategoryNone.
+func (r *RuStemmer) Analyze(word string) (stem string, suffixes []string, category string) {
+	stem, trace := r.GetWordBaseTrace(word)
+
+	suffixes = []string{}
+	for _, step := range trace {
+		if step.Step == StepPrefix {
+			continue
+		}
+		suffixes = append(suffixes, step.Suffix)
+
+		switch step.Step {
+		case StepPerfectiveGerund:
+			category = CategoryGerund
+		case StepReflexive:
+			category = CategoryReflexive
+		case StepAdjectival:
+			category = r.adjectivalCategory(step.Suffix)
+		case StepVerb:
+			category = CategoryVerb
+		case StepNoun:
+			category = CategoryNoun
+		case StepDerivational:
+			if category == CategoryNone {
+				category = CategoryNoun
+			}
+		case StepSuperlative:
+			if category == CategoryNone {
+				category = CategoryAdjective
+			}
+		}
+	}
+
+	return stem, suffixes, category
+}
+
+// adjectivalCategory returns CategoryAdjective if the ending removed by the adjectival step
+// is an adjective ending, and CategoryParticiple if it starts with a participle suffix.
+func (r *RuStemmer) adjectivalCategory(suffix string) string {
+	runes := []rune(suffix)
+	if r.suffixAdjective.Match(runes, "") == len(runes) {
+		return CategoryAdjective
+	}
+
+	return CategoryParticiple
+}
@@ -0,0 +1,46 @@
+package rustemmer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	testWords := map[string]struct {
+		stem     string
+		suffixes []string
+		category string
+	}{
+		"прочитав"     : {"прочита", []string{"в"}, CategoryGerund},
+		"делавшийся"   : {"дела", []string{"ся", "вший"}, CategoryParticiple},
+		"читаемый"     : {"чита", []string{"емый"}, CategoryParticiple},
+		"красивыми"    : {"красив", []string{"ыми"}, CategoryAdjective},
+		"ценнейший"    : {"цен", []string{"ий", "ейш", "н"}, CategoryAdjective},
+		"поставили"    : {"постав", []string{"или"}, CategoryVerb},
+		"валялся"      : {"валя", []string{"ся", "л"}, CategoryVerb},
+		"вагоны"       : {"вагон", []string{"ы"}, CategoryNoun},
+		"бдительность" : {"бдительн", []string{"ь", "ост"}, CategoryNoun},
+		"старейш"      : {"стар", []string{"ейш"}, CategoryAdjective},
+		"вагон"        : {"вагон", []string{}, CategoryNone},
+	}
+
+	for word, expected := range testWords {
+		stem, suffixes, category := Analyze(word)
+		if stem != expected.stem || !reflect.DeepEqual(expected.suffixes, suffixes) || category != expected.category {
+			t.Errorf("Not equal: [%s] %s %v %q != %s %v %q", word, expected.stem, expected.suffixes, expected.category, stem, suffixes, category)
+		}
+	}
+
+	stemmer := New(WithPrefixStripping([]string{"про"}))
+	if stem, suffixes, category := stemmer.Analyze("прочитав"); stem != "чита" || !reflect.DeepEqual(suffixes, []string{"в"}) || category != CategoryGerund {
+		t.Errorf("Not equal: чита [в] gerund != %s %v %s", stem, suffixes, category)
+	}
+
+	// The bases of exceptions and of the dictionary are reported as GetWordBase returns them.
+	stemmer = New(WithExceptions(map[string]string{"люди": "человек"}), WithDictionary(BuildDictionary([]string{"вазы"})))
+	for word, expected := range map[string]string{"люди": "человек", "вазы": "ваз"} {
+		if stem, suffixes, category := stemmer.Analyze(word); stem != expected || len(suffixes) != 0 || category != CategoryNone {
+			t.Errorf("Not equal: [%s] %s [] != %s %v %q", word, expected, stem, suffixes, category)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package rustemmer
+
+import (
+	"unicode/utf8"
+
+	"github.com/liderman/rustemmer/internal/porter"
+)
+
+// StemAppend appends the base of the word to dst and returns the extended buffer.
+func StemAppend(dst []byte, word string) []byte {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemAppend(dst, word)
+}
+
+// StemAppend appends the base of the word to dst and returns the extended buffer, like GetWordBase
+// followed by append. Without options that rewrite the word, such as WithCaseFolding, it allocates
+// only when dst has to grow, so reusing the buffer avoids allocations in hot loops.
+func (r *RuStemmer) StemAppend(dst []byte, word string) []byte {
+	if r.caseHandling == CasePreserve || r.cache != nil || r.dictionary != nil || r.lemmas != nil || r.exceptions != nil || r.nonRussianStemmer != nil || r.outputTransform != nil || r.stats != nil || r.invalidWordPolicy == InvalidWordReplace || r.isPassedThrough(word) || r.isStemmedByParts(word) {
+		return append(dst, r.GetWordBase(word)...)
+	}
+
+	word = r.prepareWord(word)
+	if utf8.RuneCountInString(word) < r.minWordLength {
+		return append(dst, word...)
+	}
+
+	head, tail := porter.SplitCyrillicTail(word)
+	dst = append(dst, head...)
+	if tail == "" {
+		return dst
+	}
+
+	start, end := r.stemRunes(tail)
+	return append(dst, tail[start:end]...)
+}
+
+// AppendNormalized appends the text normalized as by NormalizeText to dst and returns the extended buffer.
+func AppendNormalized(dst []byte, text string) []byte {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.AppendNormalized(dst, text)
+}
+
+// AppendNormalized appends the text normalized as by NormalizeText to dst and returns the extended buffer.
+// The bases are appended with StemAppend, so, unlike NormalizeText, the words and their bases are not
+// collected into strings. With the default tokenizer or one set with WithWordChars, and the default options,
+// it allocates only when dst has to grow.
+func (r *RuStemmer) AppendNormalized(dst []byte, text string) []byte {
+	isWordChar, apostrophes := wordCharFunc(r.tokenizer)
+	if isWordChar == nil || r.alphanumericPolicy == NumberSplit {
+		for k, loc := range r.findWordIndexes(text) {
+			if k > 0 {
+				dst = append(dst, r.separator...)
+			}
+			dst = r.StemAppend(dst, text[loc[0]:loc[1]])
+		}
+		return dst
+	}
+
+	first := true
+	for start, end := nextWord(text, 0, isWordChar, apostrophes); start >= 0; start, end = nextWord(text, end, isWordChar, apostrophes) {
+		word := text[start:end]
+		if r.filtersWords() && r.skipWord(word) {
+			continue
+		}
+		if !first {
+			dst = append(dst, r.separator...)
+		}
+		dst = r.StemAppend(dst, word)
+		first = false
+	}
+
+	return dst
+}
+
+// wordCharFunc returns the predicate of the word characters of the tokenizer, or nil if it does not find words
+// as runs of characters, and reports whether apostrophes followed by letters are part of words.
+func wordCharFunc(tokenizer Tokenizer) (func(char rune) bool, bool) {
+	if t, ok := tokenizer.(charClassTokenizer); ok {
+		return t.isWordChar, false
+	}
+	if tokenizer == wordTokenizer {
+		return isWordChar, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,96 @@
+package rustemmer
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestStemAppend(t *testing.T) {
+	file, err := os.Open("testdata/stems.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	words := []string{"", "вазы", "Samsung", "USBшники", "31", "бои\u0306цы"}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		words = append(words, strings.Fields(scanner.Text())[0])
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	stemmers := []*RuStemmer{
+		New(),
+		New(WithCaseFolding(), WithYoNormalization()),
+		New(WithMinWordLength(5)),
+		New(WithCache(10)),
+	}
+	for _, stemmer := range stemmers {
+		dst := []byte("prefix:")
+		for _, word := range words {
+			result := stemmer.StemAppend(dst, word)
+			if expected := "prefix:" + stemmer.GetWordBase(word); string(result) != expected {
+				t.Errorf("Not equal: [%s] %s != %s", word, expected, result)
+			}
+		}
+	}
+}
+
+func TestStemAppendAllocs(t *testing.T) {
+	stemmer := New()
+	dst := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		dst = stemmer.StemAppend(dst[:0], "важнейшими")
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func TestAppendNormalized(t *testing.T) {
+	texts := []string{
+		"г. Москва, ул. Полярная, д. 31А, стр. 1. Ёлки и БОЙЦЫ",
+		"", " ", "вазы", "snake_case слово_с_подчёркиванием", "бои\u0306цы и зеле\u0308ные",
+		"\xffвазы вазы\xd1 ва\xe2\x82зы", "١٢٣ цифры²", "Windows10-вагоны",
+	}
+	stemmers := []*RuStemmer{
+		New(),
+		New(WithCaseFolding(), WithYoNormalization(), WithRussianStopWords()),
+		New(WithOutputSeparator("|"), WithCache(10)),
+		New(WithAlphanumericPolicy(NumberSplit), WithNumberPolicy(NumberDrop)),
+		New(WithTokenizerPattern("[\\p{L}-]+")),
+		New(WithWordChars(unicode.IsLetter)),
+	}
+	for _, stemmer := range stemmers {
+		for _, text := range texts {
+			result := stemmer.AppendNormalized([]byte("prefix:"), text)
+			if expected := "prefix:" + stemmer.NormalizeText(text); string(result) != expected {
+				t.Errorf("Not equal: %q != %q", expected, result)
+			}
+		}
+	}
+
+	if result := AppendNormalized(nil, "Важные новости"); string(result) != "Важн новост" {
+		t.Errorf("Not equal: Важн новост != %s", result)
+	}
+	if result := AppendNormalized([]byte("x"), ""); string(result) != "x" {
+		t.Errorf("Not equal: x != %s", result)
+	}
+}
+
+func TestAppendNormalizedAllocs(t *testing.T) {
+	dst := make([]byte, 0, 256)
+	for _, stemmer := range []*RuStemmer{New(), New(WithWordChars(unicode.IsLetter))} {
+		allocs := testing.AllocsPerRun(100, func() {
+			dst = stemmer.AppendNormalized(dst[:0], "Важные новости: вагоны метро стоят в депо")
+		})
+		if allocs != 0 {
+			t.Errorf("Expected no allocations, got %v", allocs)
+		}
+	}
+}
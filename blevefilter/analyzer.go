@@ -0,0 +1,41 @@
+package blevefilter
+
+import (
+	"github.com/blevesearch/bleve/analysis"
+	"github.com/blevesearch/bleve/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/registry"
+)
+
+// AnalyzerName is the name the analyzer for Russian text is registered under in the Bleve registry.
+// It may be used as the analyzer of a field mapping or as the default analyzer of an index mapping
+// without defining a custom analyzer:
+//
+//	indexMapping.DefaultAnalyzer = blevefilter.AnalyzerName
+const AnalyzerName = "ru_rustemmer"
+
+// AnalyzerConstructor creates an analyzer for the Bleve registry that splits text with the Unicode tokenizer,
+// converts the terms to lower case and replaces them with their bases. The analyzer has no configuration.
+func AnalyzerConstructor(config map[string]interface{}, cache *registry.Cache) (*analysis.Analyzer, error) {
+	tokenizer, err := cache.TokenizerNamed(unicode.Name)
+	if err != nil {
+		return nil, err
+	}
+	toLowerFilter, err := cache.TokenFilterNamed(lowercase.Name)
+	if err != nil {
+		return nil, err
+	}
+	stemmerFilter, err := cache.TokenFilterNamed(Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &analysis.Analyzer{
+		Tokenizer:    tokenizer,
+		TokenFilters: []analysis.TokenFilter{toLowerFilter, stemmerFilter},
+	}, nil
+}
+
+func init() {
+	registry.RegisterAnalyzer(AnalyzerName, AnalyzerConstructor)
+}
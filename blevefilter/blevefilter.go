@@ -0,0 +1,51 @@
+// Package blevefilter provides a Bleve token filter that replaces terms with their Russian word bases.
+//
+// Importing the package registers the filter in the Bleve registry under the name "stemmer_ru_rustemmer",
+// so it can be used in custom analyzers:
+//
+//	err := indexMapping.AddCustomAnalyzer("ru", map[string]interface{}{
+//		"type":          custom.Name,
+//		"tokenizer":     unicode.Name,
+//		"token_filters": []string{lowercase.Name, blevefilter.Name},
+//	})
+package blevefilter
+
+import (
+	"github.com/blevesearch/bleve/analysis"
+	"github.com/blevesearch/bleve/registry"
+	"github.com/liderman/rustemmer"
+)
+
+// Name is the name the filter is registered under in the Bleve registry.
+const Name = "stemmer_ru_rustemmer"
+
+// RussianStemmerFilter is a Bleve token filter that replaces the term of each token with its base.
+// Position, Start and End of the tokens are kept. Tokens marked as keywords are left unchanged.
+// A RussianStemmerFilter is safe for concurrent use.
+type RussianStemmerFilter struct{}
+
+// NewRussianStemmerFilter creates a new RussianStemmerFilter.
+func NewRussianStemmerFilter() *RussianStemmerFilter {
+	return &RussianStemmerFilter{}
+}
+
+// Filter replaces the term of every non-keyword token of the input with its base.
+func (f *RussianStemmerFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		if token.KeyWord {
+			continue
+		}
+		token.Term = []byte(rustemmer.GetWordBase(string(token.Term)))
+	}
+
+	return input
+}
+
+// Constructor creates a RussianStemmerFilter for the Bleve registry. The filter has no configuration.
+func Constructor(config map[string]interface{}, cache *registry.Cache) (analysis.TokenFilter, error) {
+	return NewRussianStemmerFilter(), nil
+}
+
+func init() {
+	registry.RegisterTokenFilter(Name, Constructor)
+}
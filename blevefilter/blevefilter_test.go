@@ -0,0 +1,89 @@
+package blevefilter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blevesearch/bleve"
+	"github.com/blevesearch/bleve/analysis"
+	"github.com/blevesearch/bleve/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/analysis/tokenizer/unicode"
+)
+
+func TestFilter(t *testing.T) {
+	input := analysis.TokenStream{
+		{Term: []byte("вазы"), Start: 0, End: 8, Position: 1},
+		{Term: []byte("вагонов"), Start: 9, End: 23, Position: 2},
+		{Term: []byte("вагонов"), Start: 24, End: 38, Position: 3, KeyWord: true},
+	}
+	expected := analysis.TokenStream{
+		{Term: []byte("ваз"), Start: 0, End: 8, Position: 1},
+		{Term: []byte("вагон"), Start: 9, End: 23, Position: 2},
+		{Term: []byte("вагонов"), Start: 24, End: 38, Position: 3, KeyWord: true},
+	}
+
+	output := NewRussianStemmerFilter().Filter(input)
+	if !reflect.DeepEqual(expected, output) {
+		t.Errorf("Not equal: %v != %v", expected, output)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	indexMapping := bleve.NewIndexMapping()
+	err := indexMapping.AddCustomAnalyzer("ru", map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{lowercase.Name, Name},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexMapping.DefaultAnalyzer = "ru"
+
+	index, err := bleve.NewMemOnly(indexMapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer index.Close()
+
+	if err := index.Index("1", map[string]string{"text": "На столе стоит ваза"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := index.Index("2", map[string]string{"text": "В вагоне метро"}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := index.Search(bleve.NewSearchRequest(bleve.NewMatchQuery("вазы")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 1 || result.Hits[0].ID != "1" {
+		t.Errorf("Expected document 1 to match, got %v", result.Hits)
+	}
+}
+
+func TestAnalyzer(t *testing.T) {
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultAnalyzer = AnalyzerName
+
+	index, err := bleve.NewMemOnly(indexMapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer index.Close()
+
+	if err := index.Index("1", map[string]string{"text": "Вагоны стоят в ДЕПО"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, query := range []string{"вагон", "Депо"} {
+		result, err := index.Search(bleve.NewSearchRequest(bleve.NewMatchQuery(query)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Total != 1 {
+			t.Errorf("Expected document 1 to match %s, got %v", query, result.Hits)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package rustemmer
+
+import (
+	"container/list"
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+)
+
+// cacheShards is the maximum number of shards of a cache, and cacheShardSize is the minimal number of words
+// held by a shard, so small caches keep a single shard and evict exactly the least recently used word.
+const (
+	cacheShards    = 16
+	cacheShardSize = 64
+)
+
+// stemCache memoizes word bases, evicting the least recently used ones.
+// It is safe for concurrent use, so one cache may be shared by several stemmers, such as the clones in Pool.
+// The words are distributed between shards by their hashes, and every shard has its own lock and eviction order,
+// so the stemmers sharing the cache rarely wait for each other. Lookups only take a read lock:
+// instead of moving a word to the front of the order, they mark it as used, and a used word reaching
+// the back of the order is given a second chance and moved to the front when a word is evicted.
+type stemCache struct {
+	size   int
+	seed   maphash.Seed
+	shards []cacheShard
+}
+
+// cacheShard is a part of a stemCache holding at most size words.
+type cacheShard struct {
+	mu      sync.RWMutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+}
+
+// CacheStats describes the usage of the cache enabled with WithCache.
+type CacheStats struct {
+	// Hits is the number of words whose base was found in the cache.
+	Hits uint64
+	// Misses is the number of words whose base had to be computed.
+	Misses uint64
+	// Size is the number of words currently in the cache.
+	Size int
+}
+
+type stemCacheEntry struct {
+	word string
+	base string
+	used atomic.Bool
+}
+
+func newStemCache(size int) *stemCache {
+	n := size / cacheShardSize
+	if n > cacheShards {
+		n = cacheShards
+	} else if n < 1 {
+		n = 1
+	}
+
+	c := &stemCache{
+		size:   size,
+		seed:   maphash.MakeSeed(),
+		shards: make([]cacheShard, n),
+	}
+	for k := range c.shards {
+		// The remainder of the size is spread over the first shards.
+		shard := &c.shards[k]
+		shard.size = size / n
+		if k < size % n {
+			shard.size++
+		}
+		shard.order = list.New()
+		shard.entries = make(map[string]*list.Element, shard.size)
+	}
+
+	return c
+}
+
+// shard returns the shard holding the word.
+func (c *stemCache) shard(word string) *cacheShard {
+	if len(c.shards) == 1 {
+		return &c.shards[0]
+	}
+
+	return &c.shards[maphash.String(c.seed, word) % uint64(len(c.shards))]
+}
+
+func (c *stemCache) get(word string) (string, bool) {
+	s := c.shard(word)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	elem, ok := s.entries[word]
+	if !ok {
+		s.misses.Add(1)
+		return "", false
+	}
+	s.hits.Add(1)
+	entry := elem.Value.(*stemCacheEntry)
+	entry.used.Store(true)
+
+	return entry.base, true
+}
+
+func (c *stemCache) add(word, base string) {
+	s := c.shard(word)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[word]; ok {
+		elem.Value.(*stemCacheEntry).base = base
+		s.order.MoveToFront(elem)
+		return
+	}
+
+	elem := s.order.PushFront(&stemCacheEntry{word: word, base: base})
+	s.entries[word] = elem
+	for s.order.Len() > s.size {
+		// The word being added is never evicted, even if the others were used since.
+		oldest := s.order.Back()
+		if entry := oldest.Value.(*stemCacheEntry); entry.used.Load() || oldest == elem {
+			entry.used.Store(false)
+			s.order.MoveToFront(oldest)
+			continue
+		}
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*stemCacheEntry).word)
+	}
+}
+
+func (c *stemCache) len() int {
+	return c.stats().Size
+}
+
+func (c *stemCache) stats() CacheStats {
+	stats := CacheStats{}
+	for k := range c.shards {
+		s := &c.shards[k]
+		s.mu.RLock()
+		stats.Hits += s.hits.Load()
+		stats.Misses += s.misses.Load()
+		stats.Size += s.order.Len()
+		s.mu.RUnlock()
+	}
+
+	return stats
+}
+
+// PoolCacheStats returns the usage statistics of the cache shared by the stemmers of Pool,
+// which is enabled with Configure(WithCache(size)). It returns zero statistics if the cache is not enabled.
+func PoolCacheStats() CacheStats {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.CacheStats()
+}
+
+// CacheStats returns the usage statistics of the cache.
+// It returns zero statistics if the cache is not enabled.
+func (r *RuStemmer) CacheStats() CacheStats {
+	if r.cache == nil {
+		return CacheStats{}
+	}
+	return r.cache.stats()
+}
+
+// resetCache drops all memoized word bases, keeping the cache size.
+func (r *RuStemmer) resetCache() {
+	if r.cache != nil {
+		r.cache = newStemCache(r.cache.size)
+	}
+}
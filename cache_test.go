@@ -0,0 +1,216 @@
+package rustemmer
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestStemCacheEviction(t *testing.T) {
+	cache := newStemCache(2)
+	cache.add("вазы", "ваз")
+	cache.add("вагоны", "вагон")
+
+	// Touch "вазы" so that "вагоны" becomes the least recently used entry.
+	if base, ok := cache.get("вазы"); !ok || base != "ваз" {
+		t.Errorf("Expected cached ваз, got %s", base)
+	}
+	cache.add("валы", "вал")
+
+	if _, ok := cache.get("вагоны"); ok {
+		t.Errorf("Expected вагоны to be evicted")
+	}
+	cached := map[string]string{
+		"вазы" : "ваз",
+		"валы" : "вал",
+	}
+	for word, base := range cached {
+		if testBase, ok := cache.get(word); !ok || testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+	if size := cache.len(); size != 2 {
+		t.Errorf("Expected 2 cached entries, got %d", size)
+	}
+}
+
+func TestStemCacheSecondChance(t *testing.T) {
+	// A used word is kept when it would be evicted, and the word added is kept even if all the others were used.
+	cache := newStemCache(1)
+	cache.add("вазы", "ваз")
+	cache.get("вазы")
+	cache.add("вагоны", "вагон")
+	if _, ok := cache.get("вагоны"); !ok {
+		t.Errorf("Expected вагоны to be cached")
+	}
+	if _, ok := cache.get("вазы"); ok {
+		t.Errorf("Expected вазы to be evicted")
+	}
+
+	cache = newStemCache(3)
+	for _, word := range []string{"вазы", "вагоны", "валы"} {
+		cache.add(word, word)
+	}
+	cache.get("вазы")
+	cache.get("вагоны")
+	cache.add("вальсы", "вальсы")
+	cached := map[string]bool{
+		"вазы"   : true,
+		"вагоны" : true,
+		"валы"   : false,
+		"вальсы" : true,
+	}
+	for word, expected := range cached {
+		if _, ok := cache.get(word); ok != expected {
+			t.Errorf("Not equal: [%s] %v != %v", word, expected, ok)
+		}
+	}
+}
+
+func TestStemCacheConcurrent(t *testing.T) {
+	cache := newStemCache(3)
+	words := []string{"вазы", "вагоны", "валы", "важные", "вальсы"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				for _, word := range words {
+					if _, ok := cache.get(word); !ok {
+						cache.add(word, word)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if size := cache.len(); size != 3 {
+		t.Errorf("Expected 3 cached entries, got %d", size)
+	}
+}
+
+func TestStemCacheShards(t *testing.T) {
+	testSizes := map[int]int{
+		1    : 1,
+		64   : 1,
+		200  : 3,
+		1000 : 15,
+		5000 : 16,
+	}
+	for size, shards := range testSizes {
+		cache := newStemCache(size)
+		if len(cache.shards) != shards {
+			t.Errorf("Not equal: [%d] %d != %d", size, shards, len(cache.shards))
+		}
+		total := 0
+		for k := range cache.shards {
+			total += cache.shards[k].size
+		}
+		if total != size {
+			t.Errorf("Not equal: [%d] %d != %d", size, size, total)
+		}
+	}
+
+	// Every shard evicts its own words, so the cache never holds more than its size.
+	cache := newStemCache(200)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				word := strings.Repeat("в", i + 1) + strconv.Itoa(j)
+				if _, ok := cache.get(word); !ok {
+					cache.add(word, word)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if stats := cache.stats(); stats.Size != 200 || stats.Misses != 4000 {
+		t.Errorf("Not equal: 200 4000 != %d %d", stats.Size, stats.Misses)
+	}
+	for k := range cache.shards {
+		if shard := &cache.shards[k]; shard.order.Len() != shard.size {
+			t.Errorf("Not equal: %d != %d", shard.size, shard.order.Len())
+		}
+	}
+}
+
+func TestCachedEqualsUncached(t *testing.T) {
+	text := "Результаты проверки города в DB: \"Санкт-Петербурга\" не нашлось! " +
+		"Важная новость (!) В вагоне метро заклинило вал. " +
+		"Глава СКР: спортсменам могли умышленно подбросить мельдоний"
+
+	uncached := New()
+	// A small capacity makes the stemmer evict words while normalizing.
+	cached := New(WithCache(4))
+	for i := 0; i < 3; i++ {
+		for _, word := range uncached.splitWords(text) {
+			if expected, result := uncached.GetWordBase(word), cached.GetWordBase(word); expected != result {
+				t.Errorf("Not equal: [%s] %s != %s", word, expected, result)
+			}
+		}
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	stemmer := New(WithCache(3))
+	for _, word := range []string{"вазы", "вагоны", "валы", "вазы"} {
+		stemmer.GetWordBase(word)
+	}
+
+	expected := CacheStats{Hits: 1, Misses: 3, Size: 3}
+	if stats := stemmer.CacheStats(); stats != expected {
+		t.Errorf("Not equal: %+v != %+v", expected, stats)
+	}
+
+	// Exceeding the capacity by one word evicts the least recently used "вагоны".
+	stemmer.GetWordBase("вальсы")
+	stemmer.GetWordBase("вагоны")
+	expected = CacheStats{Hits: 1, Misses: 5, Size: 3}
+	if stats := stemmer.CacheStats(); stats != expected {
+		t.Errorf("Not equal: %+v != %+v", expected, stats)
+	}
+
+	if stats := New().CacheStats(); stats != (CacheStats{}) {
+		t.Errorf("Expected zero statistics, got %+v", stats)
+	}
+}
+
+func TestPoolCacheStats(t *testing.T) {
+	Configure(WithCache(10), WithCaseHandling(CasePreserve))
+	defer Configure()
+
+	for _, word := range []string{"Который", "который", "КОТОРЫЙ", "быть"} {
+		GetWordBase(word)
+	}
+	expected := CacheStats{Hits: 2, Misses: 2, Size: 2}
+	if stats := PoolCacheStats(); stats != expected {
+		t.Errorf("Not equal: %+v != %+v", expected, stats)
+	}
+
+	Configure()
+	if stats := PoolCacheStats(); stats != (CacheStats{}) {
+		t.Errorf("Expected zero statistics, got %+v", stats)
+	}
+}
+
+func TestCacheKeyPrepared(t *testing.T) {
+	stemmer := New(WithCache(10), WithCaseFolding(), WithYoNormalization())
+	for _, word := range []string{"Берёзами", "березами", "БЕРЕЗАМИ"} {
+		if base := stemmer.GetWordBase(word); base != "берез" {
+			t.Errorf("Not equal: [%s] берез != %s", word, base)
+		}
+	}
+
+	expected := CacheStats{Hits: 2, Misses: 1, Size: 1}
+	if stats := stemmer.CacheStats(); stats != expected {
+		t.Errorf("Not equal: %+v != %+v", expected, stats)
+	}
+}
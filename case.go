@@ -0,0 +1,75 @@
+package rustemmer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CaseHandling selects how the stemmer treats the letter case of words.
+type CaseHandling int
+
+const (
+	// CaseOriginal stems words as they are written, which is the default. Suffixes and vowels
+	// are matched in lower case only, so words written in upper case are usually left unstemmed.
+	CaseOriginal CaseHandling = iota
+	// CaseLower converts words to lower case before stemming, as WithCaseFolding does.
+	CaseLower
+	// CasePreserve stems words in lower case and then restores the capitalization of the word in its base,
+	// so "Москва" becomes "Москв" and "МОСКВА" becomes "МОСКВ".
+	CasePreserve
+)
+
+// WithCaseHandling sets how the stemmer treats the letter case of words. The default is CaseOriginal.
+func WithCaseHandling(mode CaseHandling) Option {
+	return func(r *RuStemmer) {
+		r.caseHandling = mode
+	}
+}
+
+// outputBase returns the base of the word as GetWordBase returns it, with the case of the word restored
+// and the transformation set with WithOutputTransform applied.
+func (r *RuStemmer) outputBase(word, base string) string {
+	base = r.restoreCase(word, base)
+	if r.outputTransform != nil {
+		base = r.outputTransform(base)
+	}
+
+	return base
+}
+
+// restoreCase returns the base of the word with the capitalization of the word restored if the stemmer
+// preserves case, and the base unchanged otherwise. As the steps only remove suffixes, the letters of the base
+// are usually at the same positions as in the word. When they are not, for instance because a prefix was removed,
+// only the capitalization of the whole word or of its first letter is restored.
+func (r *RuStemmer) restoreCase(word, base string) string {
+	if r.caseHandling != CasePreserve {
+		return base
+	}
+	word = r.normalizeUnicode(word)
+	if strings.IndexFunc(word, unicode.IsUpper) < 0 {
+		return base
+	}
+
+	aligned := strings.HasPrefix(r.prepareWord(word), base)
+	allUpper := strings.IndexFunc(word, unicode.IsLower) < 0
+
+	var buf strings.Builder
+	buf.Grow(len(base))
+	rest := word
+	for i := 0; i < len(base); {
+		char, size := utf8.DecodeRuneInString(base[i:])
+		orig, origSize := utf8.DecodeRuneInString(rest)
+		rest = rest[origSize:]
+		if char == utf8.RuneError && size == 1 {
+			buf.WriteByte(base[i])
+		} else if aligned && unicode.IsUpper(orig) || !aligned && (allUpper || i == 0 && unicode.IsUpper(orig)) {
+			buf.WriteRune(unicode.ToUpper(char))
+		} else {
+			buf.WriteRune(char)
+		}
+		i += size
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,56 @@
+package rustemmer
+
+import (
+	"testing"
+)
+
+func TestWithCaseHandling(t *testing.T) {
+	testWords := []struct {
+		mode     CaseHandling
+		word     string
+		expected string
+	}{
+		{CaseOriginal, "Москва", "Москв"},
+		{CaseOriginal, "МОСКВА", "МОСКВА"},
+		{CaseLower, "МОСКВА", "москв"},
+		{CaseLower, "Москва", "москв"},
+		{CasePreserve, "Москва", "Москв"},
+		{CasePreserve, "МОСКВА", "МОСКВ"},
+		{CasePreserve, "Ёлками", "Ёлк"},
+		{CasePreserve, "вагоны", "вагон"},
+		{CasePreserve, "ВаГоНы", "ВаГоН"},
+		{CasePreserve, "iPhoneвазы", "iPhoneваз"},
+		{CasePreserve, "Windows", "Windows"},
+	}
+
+	for _, test := range testWords {
+		if base := New(WithCaseHandling(test.mode)).GetWordBase(test.word); base != test.expected {
+			t.Errorf("Not equal: %s != %s", test.expected, base)
+		}
+	}
+}
+
+func TestCasePreserveOptions(t *testing.T) {
+	stemmer := New(WithCaseHandling(CasePreserve), WithYoNormalization(), WithCache(10))
+	// The cache is keyed by the lower case word, so the case is restored for every spelling.
+	for _, test := range [][2]string{{"ЁЛКАМИ", "ЕЛК"}, {"ёлками", "елк"}, {"Ёлками", "Елк"}} {
+		if base := stemmer.GetWordBase(test[0]); base != test[1] {
+			t.Errorf("Not equal: %s != %s", test[1], base)
+		}
+	}
+
+	if text := stemmer.NormalizeText("Важные НОВОСТИ"); text != "Важн НОВОСТ" {
+		t.Errorf("Not equal: Важн НОВОСТ != %s", text)
+	}
+	if buf := stemmer.StemAppend(nil, "Вагоны"); string(buf) != "Вагон" {
+		t.Errorf("Not equal: Вагон != %s", buf)
+	}
+	if stem, changed := stemmer.GetWordBaseInfo("Москв"); stem != "Москв" || changed {
+		t.Errorf("Not equal: Москв false != %s %v", stem, changed)
+	}
+
+	stemmer = New(WithCaseHandling(CasePreserve), WithPrefixStripping([]string{"пере"}))
+	if base := stemmer.GetWordBase("ПЕРЕПИСАЛИ"); base != "ПИСА" {
+		t.Errorf("Not equal: ПИСА != %s", base)
+	}
+}
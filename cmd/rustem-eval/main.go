@@ -0,0 +1,236 @@
+// Command rustem-eval measures the accuracy of the stemmer on a corpus of words with their expected bases
+// or lemmas, so changes of the algorithm can be compared before they are merged.
+//
+// Usage:
+//
+//	rustem-eval [flags] [file ...]
+//
+// Every line of the files, or of the standard input if there are none, holds a word form and its expected
+// base separated by a tab. Empty lines and lines starting with "#" are skipped. Words are compared in lower case.
+// The flags are:
+//
+//	-lemmas
+//		the second column holds lemmas, as produced by a lemmatizer, and the expected base of a form
+//		is the base the stemmer finds for its lemma
+//	-top n
+//		report the n most frequent expected endings, 20 by default; 0 reports all of them
+//	-errors
+//		also write every form whose base is not the expected one
+//
+// Each base is counted as correct, over-stemmed if it is shorter than the expected base and a prefix of it,
+// under-stemmed if it is longer and the expected base is a prefix of it, or different otherwise.
+// The counts are reported in total and for the expected endings, the parts of the forms after their expected bases.
+// A file named "-" is the standard input.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/liderman/rustemmer"
+)
+
+// maxLineSize is the maximum length of a line of the input in bytes.
+const maxLineSize = 1024 * 1024
+
+// otherEnding is the ending reported for the forms that do not start with their expected bases.
+const otherEnding = "(other)"
+
+// Outcomes of the comparison of a base with the expected one.
+const (
+	correct = iota
+	over
+	under
+	different
+	outcomes
+)
+
+// outcomeNames are the names of the outcomes in the report.
+var outcomeNames = [outcomes]string{"correct", "over", "under", "different"}
+
+// counts holds the number of bases of each outcome.
+type counts [outcomes]int
+
+// total returns the number of bases.
+func (c counts) total() int {
+	n := 0
+	for _, count := range c {
+		n += count
+	}
+
+	return n
+}
+
+// evaluation collects the outcomes in total and by the expected ending.
+type evaluation struct {
+	total   counts
+	endings map[string]*counts
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "rustem-eval:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run executes the command with the arguments, without the program name.
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("rustem-eval", flag.ContinueOnError)
+	lemmas := flags.Bool("lemmas", false, "the second column holds lemmas rather than bases")
+	top := flags.Int("top", 20, "report the `n` most frequent expected endings, or all for 0")
+	showErrors := flags.Bool("errors", false, "write every form whose base is not the expected one")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(stdout)
+	stemmer := rustemmer.New(rustemmer.WithCaseFolding())
+	e := &evaluation{endings: map[string]*counts{}}
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, name := range files {
+		if err := e.processFile(name, stdin, out, stemmer, *lemmas, *showErrors); err != nil {
+			return err
+		}
+	}
+
+	e.report(out, *top)
+	return out.Flush()
+}
+
+// processFile evaluates the pairs of the file named name, which is stdin for "-",
+// writing the forms with unexpected bases to out if showErrors is set.
+func (e *evaluation) processFile(name string, stdin io.Reader, out io.Writer, stemmer *rustemmer.RuStemmer, lemmas, showErrors bool) error {
+	src := stdin
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		src = file
+	}
+
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(nil, maxLineSize)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			return fmt.Errorf("%s:%d: expected a form and a base separated by a tab", name, n)
+		}
+
+		form := strings.ToLower(strings.TrimSpace(fields[0]))
+		expected := strings.ToLower(strings.TrimSpace(fields[1]))
+		if lemmas {
+			expected = stemmer.GetWordBase(expected)
+		}
+		base := stemmer.GetWordBase(form)
+
+		outcome := compare(base, expected)
+		e.add(ending(form, expected), outcome)
+		if showErrors && outcome != correct {
+			fmt.Fprintf(out, "%s\t%s\t%s\t%s\n", outcomeNames[outcome], form, expected, base)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// compare returns the outcome of the base found for a word with the expected base.
+func compare(base, expected string) int {
+	switch {
+	case base == expected:
+		return correct
+	case strings.HasPrefix(expected, base):
+		return over
+	case strings.HasPrefix(base, expected):
+		return under
+	default:
+		return different
+	}
+}
+
+// ending returns the part of the form after its expected base, or otherEnding if the form does not start with it.
+func ending(form, expected string) string {
+	if !strings.HasPrefix(form, expected) {
+		return otherEnding
+	}
+
+	return "-" + form[len(expected):]
+}
+
+// add counts the outcome for a form with the expected ending.
+func (e *evaluation) add(ending string, outcome int) {
+	c, ok := e.endings[ending]
+	if !ok {
+		c = &counts{}
+		e.endings[ending] = c
+	}
+	c[outcome]++
+	e.total[outcome]++
+}
+
+// report writes the shares of the outcomes in total and for the top most frequent endings.
+func (e *evaluation) report(out io.Writer, top int) {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintf(w, "words\t%d\n", e.total.total())
+	for outcome, name := range outcomeNames {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", name, e.total[outcome], percent(e.total[outcome], e.total.total()))
+	}
+
+	endings := make([]string, 0, len(e.endings))
+	for ending := range e.endings {
+		endings = append(endings, ending)
+	}
+	sort.Slice(endings, func(i, j int) bool {
+		a, b := e.endings[endings[i]].total(), e.endings[endings[j]].total()
+		if a != b {
+			return a > b
+		}
+		return endings[i] < endings[j]
+	})
+	if top > 0 && top < len(endings) {
+		endings = endings[:top]
+	}
+	if len(endings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nending\twords\t%s\n", strings.Join(outcomeNames[:], "\t"))
+	for _, ending := range endings {
+		c := e.endings[ending]
+		fmt.Fprintf(w, "%s\t%d", ending, c.total())
+		for _, count := range c {
+			fmt.Fprintf(w, "\t%s", percent(count, c.total()))
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// percent returns n as a percentage of total.
+func percent(n, total int) string {
+	if total == 0 {
+		return "0.00%"
+	}
+
+	return fmt.Sprintf("%.2f%%", 100 * float64(n) / float64(total))
+}
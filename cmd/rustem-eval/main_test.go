@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	input := "# form\tbase\nвагоны\tвагон\nВагонами\tвагон\n\nстоят\tстоя\nлюдьми\tлю\nлюди\tчеловек\n"
+	var out bytes.Buffer
+	if err := run([]string{"-errors", "-top", "2"}, strings.NewReader(input), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "over\tстоят\tстоя\tсто\n" +
+		"under\tлюдьми\tлю\tлюдьм\n" +
+		"different\tлюди\tчеловек\tлюд\n" +
+		"words      5\n" +
+		"correct    2  40.00%\n" +
+		"over       1  20.00%\n" +
+		"under      1  20.00%\n" +
+		"different  1  20.00%\n" +
+		"\n" +
+		"ending   words  correct  over   under  different\n" +
+		"(other)  1      0.00%    0.00%  0.00%  100.00%\n" +
+		"-ами     1      100.00%  0.00%  0.00%  0.00%\n"
+	if out.String() != expected {
+		t.Errorf("Not equal: %q != %q", expected, out.String())
+	}
+}
+
+func TestRunLemmas(t *testing.T) {
+	dir := t.TempDir()
+	corpus := filepath.Join(dir, "corpus.tsv")
+	if err := os.WriteFile(corpus, []byte("вагонами\tвагон\nстояли\tстоять\nлюдьми\tчеловек\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"-lemmas", "-top", "0", corpus}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "correct    2  66.67%\n") {
+		t.Errorf("Unexpected report %q", out.String())
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	var out bytes.Buffer
+	if err := run(nil, strings.NewReader("вагоны вагон\n"), &out); err == nil || !strings.Contains(err.Error(), "-:1:") {
+		t.Errorf("Expected an error for line 1, got %v", err)
+	}
+	if err := run([]string{"/nonexistent"}, nil, &out); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
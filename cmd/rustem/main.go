@@ -0,0 +1,222 @@
+// Command rustem reads Russian text from the files given as arguments, or from the standard input
+// if there are none, and writes the bases of its words to the standard output.
+//
+// Usage:
+//
+//	rustem [flags] [file ...]
+//
+// By default every base is written on its own line. The flags are:
+//
+//	-normalize
+//		write every line of the text with its words replaced with their bases, as NormalizeText does
+//	-tsv
+//		write every word and its base separated by a tab, one pair per line
+//	-stopwords file
+//		skip the stop words listed in the file, one per line
+//	-russian-stopwords
+//		skip the built-in Russian stop words, together with those of -stopwords if it is given
+//	-parallel n
+//		stem the lines with n workers; the output keeps the order of the input
+//
+// A file named "-" is the standard input.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/liderman/rustemmer"
+)
+
+// batchSize is the number of lines stemmed at once, which bounds the memory used with -parallel.
+const batchSize = 1024
+
+// maxLineSize is the maximum length of a line of the input in bytes.
+const maxLineSize = 1024 * 1024
+
+// formatFunc returns the output lines for a line of the input.
+type formatFunc func(stemmer *rustemmer.RuStemmer, line string) []string
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "rustem:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run executes the command with the arguments, without the program name.
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("rustem", flag.ContinueOnError)
+	normalize := flags.Bool("normalize", false, "write every line with its words replaced with their bases")
+	tsv := flags.Bool("tsv", false, "write every word and its base separated by a tab")
+	stopWords := flags.String("stopwords", "", "skip the stop words listed in the `file`")
+	russianStopWords := flags.Bool("russian-stopwords", false, "skip the built-in Russian stop words")
+	workers := flags.Int("parallel", 1, "stem with `n` workers")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *normalize && *tsv {
+		return errors.New("-normalize and -tsv are mutually exclusive")
+	}
+
+	stemmer := rustemmer.New()
+	if *stopWords != "" || *russianStopWords {
+		words, err := readStopWords(*stopWords, *russianStopWords)
+		if err != nil {
+			return err
+		}
+		stemmer.SetStopWords(words)
+	}
+
+	format := formatStems
+	if *normalize {
+		format = formatNormalized
+	} else if *tsv {
+		format = formatTSV
+	}
+
+	out := bufio.NewWriter(stdout)
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, name := range files {
+		if err := processFile(name, stdin, out, stemmer, format, *workers); err != nil {
+			return err
+		}
+	}
+
+	return out.Flush()
+}
+
+// readStopWords returns the stop words listed in the file named name, if any,
+// and the built-in Russian stop words if russian is set.
+func readStopWords(name string, russian bool) ([]string, error) {
+	words := []string{}
+	if russian {
+		words = rustemmer.RussianStopWords()
+	}
+	if name == "" {
+		return words, nil
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	loaded, err := rustemmer.LoadStopWords(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(words, loaded...), nil
+}
+
+// processFile writes the output for the file named name, which is stdin for "-".
+func processFile(name string, stdin io.Reader, out *bufio.Writer, stemmer *rustemmer.RuStemmer, format formatFunc, workers int) error {
+	src := stdin
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		src = file
+	}
+
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(nil, maxLineSize)
+	lines := make([]string, 0, batchSize)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if len(lines) == batchSize {
+			if err := writeOutput(out, formatLines(stemmer, format, lines, workers)); err != nil {
+				return err
+			}
+			lines = lines[:0]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return writeOutput(out, formatLines(stemmer, format, lines, workers))
+}
+
+// writeOutput writes the output lines produced for the lines of the input.
+func writeOutput(out *bufio.Writer, output [][]string) error {
+	for _, lines := range output {
+		for _, line := range lines {
+			out.WriteString(line)
+			if err := out.WriteByte('\n'); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// formatLines returns the output for each of the lines, splitting the lines between the workers.
+// Every worker uses its own clone of the stemmer.
+func formatLines(stemmer *rustemmer.RuStemmer, format formatFunc, lines []string, workers int) [][]string {
+	ret := make([][]string, len(lines))
+	if workers <= 1 {
+		for k, line := range lines {
+			ret[k] = format(stemmer, line)
+		}
+		return ret
+	}
+
+	chunkSize := (len(lines) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(lines); start += chunkSize {
+		end := start + chunkSize
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			clone := stemmer.Clone()
+			for k := start; k < end; k++ {
+				ret[k] = format(clone, lines[k])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return ret
+}
+
+// formatStems returns the bases of the words of the line.
+func formatStems(stemmer *rustemmer.RuStemmer, line string) []string {
+	return stemmer.NormalizeWords(line)
+}
+
+// formatNormalized returns the line with its words replaced with their bases.
+func formatNormalized(stemmer *rustemmer.RuStemmer, line string) []string {
+	return []string{stemmer.NormalizeText(line)}
+}
+
+// formatTSV returns the words of the line, each followed by a tab and its base.
+func formatTSV(stemmer *rustemmer.RuStemmer, line string) []string {
+	tokens := stemmer.Tokenize(line)
+	ret := make([]string, len(tokens))
+	for k, token := range tokens {
+		ret[k] = token.Original + "\t" + token.Stem
+	}
+
+	return ret
+}
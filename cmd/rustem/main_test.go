@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	input := "Важные новости:\n\nвагоны стоят в депо\n"
+	testArgs := []struct {
+		args     []string
+		expected string
+	}{
+		{nil, "Важн\nновост\nвагон\nсто\nв\nдеп\n"},
+		{[]string{"-normalize"}, "Важн новост\n\nвагон сто в деп\n"},
+		{[]string{"--tsv"}, "Важные\tВажн\nновости\tновост\nвагоны\tвагон\nстоят\tсто\nв\tв\nдепо\tдеп\n"},
+		{[]string{"-normalize", "-russian-stopwords"}, "Важн новост\n\nвагон сто деп\n"},
+		{[]string{"-normalize", "-parallel", "4", "-"}, "Важн новост\n\nвагон сто в деп\n"},
+	}
+
+	for _, test := range testArgs {
+		var out bytes.Buffer
+		if err := run(test.args, strings.NewReader(input), &out); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != test.expected {
+			t.Errorf("Not equal: %q != %q", test.expected, out.String())
+		}
+	}
+}
+
+func TestRunFiles(t *testing.T) {
+	dir := t.TempDir()
+	text := filepath.Join(dir, "text.txt")
+	stopWords := filepath.Join(dir, "stopwords.txt")
+	if err := os.WriteFile(text, []byte("вагоны стоят в депо"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stopWords, []byte("в\nСТОЯТ\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	args := []string{"-normalize", "-stopwords", stopWords, text, "-", text}
+	if err := run(args, strings.NewReader("Важные новости"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "вагон деп\nВажн новост\nвагон деп\n"; out.String() != expected {
+		t.Errorf("Not equal: %q != %q", expected, out.String())
+	}
+
+	// The built-in stop words are added to those of the file.
+	out.Reset()
+	args = []string{"-normalize", "-russian-stopwords", "-stopwords", stopWords}
+	if err := run(args, strings.NewReader("И вагоны стоят"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "вагон\n"; out.String() != expected {
+		t.Errorf("Not equal: %q != %q", expected, out.String())
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	testArgs := [][]string{
+		{"-normalize", "-tsv"},
+		{"-unknown"},
+		{filepath.Join(t.TempDir(), "missing.txt")},
+		{"-stopwords", filepath.Join(t.TempDir(), "missing.txt")},
+	}
+
+	for _, args := range testArgs {
+		if err := run(args, strings.NewReader(""), &bytes.Buffer{}); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
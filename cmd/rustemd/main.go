@@ -0,0 +1,253 @@
+// Command rustemd is an HTTP server stemming Russian words and texts for services written in other languages.
+//
+// Usage:
+//
+//	rustemd [flags]
+//
+// The flags are:
+//
+//	-addr address
+//		listen on the TCP address, ":8080" by default
+//	-stopwords file
+//		skip the stop words listed in the file, one per line
+//	-russian-stopwords
+//		skip the built-in Russian stop words, together with those of -stopwords if it is given
+//
+// The endpoints are:
+//
+//	POST /stem
+//		returns the bases of words
+//	POST /normalize
+//		returns texts with their words replaced with their bases, as NormalizeText does
+//	GET /healthz
+//		returns "ok" while the server is running
+//
+// A request with the content type "application/json" has a body that is a string or an array of strings,
+// and the response has the same shape: "вагоны" gives "вагон" and ["вагоны", "стоят"] gives ["вагон", "сто"].
+// For /stem every string is a single word, for /normalize it is a text.
+// Any other body is plain text, and the response is plain text too: /stem writes the bases of the words
+// of the text one per line, and /normalize writes every line of the text with its words replaced with their bases.
+//
+// On SIGINT or SIGTERM the server stops accepting connections and finishes the requests in progress before exiting.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/liderman/rustemmer"
+)
+
+// maxBodySize is the maximum size of a request body in bytes.
+const maxBodySize = 10 * 1024 * 1024
+
+// shutdownTimeout is how long the server waits for the requests in progress when it stops.
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "rustemd:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run starts the server with the arguments, without the program name, and serves until ctx is done.
+func run(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("rustemd", flag.ContinueOnError)
+	addr := flags.String("addr", ":8080", "listen on the TCP `address`")
+	stopWords := flags.String("stopwords", "", "skip the stop words listed in the `file`")
+	russianStopWords := flags.Bool("russian-stopwords", false, "skip the built-in Russian stop words")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts := []rustemmer.Option{}
+	if *stopWords != "" || *russianStopWords {
+		words, err := readStopWords(*stopWords, *russianStopWords)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, rustemmer.WithStopWords(words))
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newHandler(opts...),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return server.Shutdown(shutdownCtx)
+}
+
+// readStopWords returns the stop words listed in the file named name, if any,
+// and the built-in Russian stop words if russian is set.
+func readStopWords(name string, russian bool) ([]string, error) {
+	words := []string{}
+	if russian {
+		words = rustemmer.RussianStopWords()
+	}
+	if name == "" {
+		return words, nil
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	loaded, err := rustemmer.LoadStopWords(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(words, loaded...), nil
+}
+
+// handler serves the endpoints with stemmers from a pool, as a RuStemmer is not safe for concurrent use.
+type handler struct {
+	pool *rustemmer.StemmerPool
+}
+
+// newHandler returns the handler of the endpoints, stemming with stemmers configured with the given options.
+func newHandler(opts ...rustemmer.Option) http.Handler {
+	h := &handler{pool: rustemmer.NewStemmerPool(opts...)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stem", h.post(stemWords, stemText))
+	mux.HandleFunc("/normalize", h.post(normalizeTexts, normalizeText))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, "ok\n")
+	})
+
+	return mux
+}
+
+// batchFunc returns the results for the strings of a JSON request.
+type batchFunc func(stemmer *rustemmer.RuStemmer, strs []string) []string
+
+// textFunc writes the result for the text of a plain text request.
+type textFunc func(stemmer *rustemmer.RuStemmer, w io.Writer, text string)
+
+// post returns a handler of POST requests, processing JSON bodies with batch and other bodies with text.
+func (h *handler) post(batch batchFunc, text textFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			} else {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			}
+			return
+		}
+
+		stemmer := h.pool.Get()
+		defer h.pool.Put(stemmer)
+
+		mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if mediaType != "application/json" {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			text(stemmer, w, string(body))
+			return
+		}
+
+		strs, isArray, err := decodeStrings(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var ret interface{} = batch(stemmer, strs)
+		if !isArray {
+			ret = ret.([]string)[0]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ret)
+	}
+}
+
+// decodeStrings decodes a JSON body that is a string or an array of strings,
+// and reports whether it is an array. A null body is neither and is rejected.
+func decodeStrings(body []byte) (strs []string, isArray bool, err error) {
+	var str *string
+	if err := json.Unmarshal(body, &str); err == nil && str != nil {
+		return []string{*str}, false, nil
+	}
+	if err := json.Unmarshal(body, &strs); err != nil || strs == nil {
+		return nil, false, errors.New("the body must be a string or an array of strings")
+	}
+
+	return strs, true, nil
+}
+
+// stemWords returns the bases of the words.
+func stemWords(stemmer *rustemmer.RuStemmer, words []string) []string {
+	return stemmer.StemTokens(words)
+}
+
+// normalizeTexts returns the texts with their words replaced with their bases.
+func normalizeTexts(stemmer *rustemmer.RuStemmer, texts []string) []string {
+	ret := make([]string, len(texts))
+	for k, text := range texts {
+		ret[k] = stemmer.NormalizeText(text)
+	}
+
+	return ret
+}
+
+// stemText writes the bases of the words of the text, one per line.
+func stemText(stemmer *rustemmer.RuStemmer, w io.Writer, text string) {
+	stemmer.ForEachStem(text, func(stem string) bool {
+		_, err := io.WriteString(w, stem + "\n")
+		return err == nil
+	})
+}
+
+// normalizeText writes every line of the text with its words replaced with their bases.
+func normalizeText(stemmer *rustemmer.RuStemmer, w io.Writer, text string) {
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line == "" {
+			continue
+		}
+		io.WriteString(w, stemmer.NormalizeText(line) + "\n")
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestHandler(t *testing.T) {
+	testRequests := []struct {
+		path        string
+		contentType string
+		body        string
+		expected    string
+	}{
+		{"/stem", "application/json", `"вагоны"`, "\"вагон\"\n"},
+		{"/stem", "application/json; charset=utf-8", `["вагоны", "стоят"]`, "[\"вагон\",\"сто\"]\n"},
+		{"/stem", "application/json", `[]`, "[]\n"},
+		{"/stem", "text/plain", "Вагоны стоят\nв депо", "Вагон\nсто\nв\nдеп\n"},
+		{"/stem", "", "вагоны", "вагон\n"},
+		{"/normalize", "application/json", `"Вагоны стоят в депо."`, "\"Вагон сто в деп\"\n"},
+		{"/normalize", "application/json", `["Вагоны стоят", "в депо"]`, "[\"Вагон сто\",\"в деп\"]\n"},
+		{"/normalize", "text/plain", "Вагоны стоят\n\nв депо\n", "Вагон сто\n\nв деп\n"},
+	}
+
+	server := httptest.NewServer(newHandler())
+	defer server.Close()
+
+	for _, test := range testRequests {
+		resp, err := http.Post(server.URL + test.path, test.contentType, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.StatusCode != http.StatusOK || string(body) != test.expected {
+			t.Errorf("Not equal: [%s %s] %q != %d %q", test.path, test.body, test.expected, resp.StatusCode, string(body))
+		}
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	handler := newHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stem", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("Not equal: %d != %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/stem", strings.NewReader(`{"word": "вагоны"}`))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Not equal: %d != %d", http.StatusBadRequest, rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/stem", strings.NewReader("null"))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Not equal: %d != %d", http.StatusBadRequest, rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/stem", strings.NewReader(strings.Repeat("вагоны ", maxBodySize / 12 + 1)))
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Not equal: %d != %d", http.StatusRequestEntityTooLarge, rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/stem", iotest.ErrReader(errors.New("connection reset")))
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Not equal: %d != %d", http.StatusBadRequest, rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok\n" {
+		t.Errorf("Not equal: 200 ok != %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRunShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := run(ctx, []string{"-addr", "127.0.0.1:0", "-russian-stopwords"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run(context.Background(), []string{"-stopwords", "/nonexistent"}); err == nil {
+		t.Error("Expected an error for a missing stop words file")
+	}
+}
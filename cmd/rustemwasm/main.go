@@ -0,0 +1,46 @@
+//go:build js && wasm
+
+// Command rustemwasm exposes the stemmer to JavaScript when compiled to WebAssembly,
+// so Russian texts can be stemmed in browsers, for instance for search as you type.
+//
+// Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o rustemmer.wasm github.com/liderman/rustemmer/cmd/rustemwasm
+//
+// and load it with wasm_exec.js from the Go distribution. Once running, it defines the global object
+// rustemmer with the functions
+//
+//	rustemmer.getWordBase(word)
+//		returns the base of the word
+//	rustemmer.normalizeText(text)
+//		returns the text with its words replaced with their bases, as NormalizeText does
+//
+// Both functions return null if their argument is not a string. The program keeps running,
+// so the functions stay available until the page is closed.
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/liderman/rustemmer"
+)
+
+func main() {
+	js.Global().Set("rustemmer", js.ValueOf(map[string]interface{}{
+		"getWordBase":   stringFunc(rustemmer.GetWordBase),
+		"normalizeText": stringFunc(rustemmer.NormalizeText),
+	}))
+
+	select {}
+}
+
+// stringFunc wraps fn as a JavaScript function of a string returning a string, or null for other arguments.
+func stringFunc(fn func(string) string) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) == 0 || args[0].Type() != js.TypeString {
+			return nil
+		}
+
+		return fn(args[0].String())
+	})
+}
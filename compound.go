@@ -0,0 +1,122 @@
+package rustemmer
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// CompoundMode defines how hyphenated compound words such as "красно-белый" are handled.
+type CompoundMode int
+
+const (
+	// CompoundSplitAndStem splits a compound word at hyphens and stems every part as a separate word.
+	// This is the default.
+	CompoundSplitAndStem CompoundMode = iota
+	// CompoundStemWhole keeps a compound word as a single word and stems it as a whole,
+	// which removes the ending of its last part. If the word ends with particles, as "какой-нибудь" does,
+	// the last part before them is stemmed instead.
+	CompoundStemWhole
+	// CompoundKeepAsIs keeps a compound word as a single word and leaves it unchanged.
+	CompoundKeepAsIs
+	// CompoundStemParts keeps a compound word as a single word and stems every part of it,
+	// as GetCompoundBase does.
+	CompoundStemParts
+)
+
+// compoundParticles are the parts of compound words, such as "кто-нибудь" and "кое-что",
+// which are particles and are never stemmed.
+var compoundParticles = map[string]bool{
+	"то"     : true,
+	"либо"   : true,
+	"нибудь" : true,
+	"ка"     : true,
+	"таки"   : true,
+	"кое"    : true,
+}
+
+// compoundWordPattern matches words joined by single inner hyphens, with apostrophes as in wordPattern.
+// Leading and trailing hyphens are not part of a word.
+const compoundWordPattern = "[\\p{L}\\p{M}\\d_]+(?:-[\\p{L}\\p{M}\\d_]+|['’ʼ][\\p{L}\\p{M}]+)*"
+
+// compoundWordTokenizer is the tokenizer that keeps compound words together.
+var compoundWordTokenizer = regexpTokenizer{regexp.MustCompile(compoundWordPattern)}
+
+// WithCompoundWordSplitting sets how hyphenated compound words are handled.
+// The tokenizer is replaced with one that splits words at hyphens for CompoundSplitAndStem and keeps
+// compound words together for the other modes, overriding WithTokenizerPattern or WithTokenizer given before it.
+func WithCompoundWordSplitting(mode CompoundMode) Option {
+	return func(r *RuStemmer) {
+		r.compoundMode = mode
+		if mode == CompoundSplitAndStem {
+			r.tokenizer = wordTokenizer
+		} else {
+			r.tokenizer = compoundWordTokenizer
+		}
+	}
+}
+
+// WithHyphenatedWords keeps words joined by inner hyphens, such as "интернет-магазины", together
+// and stems every part of them when enabled; it is a shorthand for WithCompoundWordSplitting(CompoundStemParts).
+// When disabled, the default, hyphenated words are split into separate words.
+func WithHyphenatedWords(enabled bool) Option {
+	if enabled {
+		return WithCompoundWordSplitting(CompoundStemParts)
+	}
+	return WithCompoundWordSplitting(CompoundSplitAndStem)
+}
+
+// GetCompoundBase returns the word in which every hyphen-separated part is replaced with its base.
+func GetCompoundBase(word string) string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.GetCompoundBase(word)
+}
+
+// GetCompoundBase returns the word in which every hyphen-separated part is replaced with its base,
+// so "интернет-магазины" becomes "интернет-магазин". Particles such as "-нибудь", "-либо" and "-то"
+// are left intact, as are leading and trailing hyphens.
+func (r *RuStemmer) GetCompoundBase(word string) string {
+	base := r.outputBase(word, r.stemCompound(word, true))
+	if r.stats != nil {
+		r.stats.WordStemmed(utf8.RuneCountInString(base))
+	}
+
+	return base
+}
+
+// stemCompound replaces the hyphen-separated parts of the word that are not particles with their bases,
+// either all of them or only the last one. The case of every part is restored separately,
+// and the output transformation is left to the caller, which applies it to the whole word.
+func (r *RuStemmer) stemCompound(word string, all bool) string {
+	parts := strings.Split(word, "-")
+	for k := len(parts) - 1; k >= 0; k-- {
+		if parts[k] != "" && !compoundParticles[strings.ToLower(parts[k])] {
+			parts[k] = r.restoreCase(parts[k], r.wordBase(parts[k]))
+			if !all {
+				break
+			}
+		}
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// isStemmedByParts reports whether the word is a compound word whose parts must be stemmed separately,
+// rather than as a whole: in the CompoundStemParts mode, and in the CompoundStemWhole mode if it ends with a particle.
+func (r *RuStemmer) isStemmedByParts(word string) bool {
+	switch r.compoundMode {
+	case CompoundStemParts:
+		return strings.Contains(word, "-")
+	case CompoundStemWhole:
+		i := strings.LastIndex(word, "-")
+		return i >= 0 && compoundParticles[strings.ToLower(word[i + 1:])]
+	}
+
+	return false
+}
+
+// isKeptCompound reports whether the word is a compound word that must be left unchanged.
+func (r *RuStemmer) isKeptCompound(word string) bool {
+	return r.compoundMode == CompoundKeepAsIs && strings.Contains(word, "-")
+}
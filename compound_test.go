@@ -0,0 +1,127 @@
+package rustemmer
+
+import (
+	"testing"
+)
+
+func TestWithCompoundWordSplitting(t *testing.T) {
+	text := "Планшет темно-синий, -вазы- и красно-белые вагоны"
+	testModes := map[CompoundMode]string{
+		CompoundSplitAndStem : "Планшет темн син ваз и красн бел вагон",
+		CompoundStemWhole    : "Планшет темно-син ваз и красно-бел вагон",
+		CompoundKeepAsIs     : "Планшет темно-синий ваз и красно-белые вагон",
+	}
+
+	for mode, expected := range testModes {
+		if result := New(WithCompoundWordSplitting(mode)).NormalizeText(text); result != expected {
+			t.Errorf("Not equal: %s != %s", expected, result)
+		}
+	}
+
+	if result := New().NormalizeText(text); result != testModes[CompoundSplitAndStem] {
+		t.Errorf("Not equal: %s != %s", testModes[CompoundSplitAndStem], result)
+	}
+}
+
+func TestCompoundStemWholeParticles(t *testing.T) {
+	testWords := map[string]string{
+		"какой-нибудь"      : "как-нибудь",
+		"кто-нибудь"        : "кто-нибудь",
+		"Кого-то"           : "Ког-то",
+		"красно-белые"      : "красно-бел",
+		"интернет-магазины" : "интернет-магазин",
+		"красно-белой-то"   : "красно-бел-то",
+	}
+
+	stemmer := New(WithCompoundWordSplitting(CompoundStemWhole))
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+}
+
+func TestWithCompoundWordSplittingPreserve(t *testing.T) {
+	stemmer := New(WithCompoundWordSplitting(CompoundStemWhole))
+	if result := stemmer.NormalizeTextPreserve("«красно-белые» вагоны"); result != "«красно-бел» вагон" {
+		t.Errorf("Not equal: «красно-бел» вагон != %s", result)
+	}
+}
+
+func TestGetCompoundBase(t *testing.T) {
+	testWords := map[string]string{
+		"интернет-магазины"       : "интернет-магазин",
+		"социально-экономические" : "социальн-экономическ",
+		"кто-нибудь"              : "кто-нибудь",
+		"какой-либо"              : "как-либо",
+		"кого-то"                 : "ког-то",
+		"кое-кого"                : "кое-ког",
+		"-вазы"                   : "-ваз",
+		"вазы-"                   : "ваз-",
+		"красно-бело-синие"       : "красн-бел-син",
+		"вазы"                    : "ваз",
+	}
+
+	for word, base := range testWords {
+		if testBase := GetCompoundBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+}
+
+func TestCompoundStemParts(t *testing.T) {
+	stemmer := New(WithCompoundWordSplitting(CompoundStemParts))
+
+	text := "Купил в интернет-магазине что-нибудь -вазы- красно-бело-синие"
+	expected := "Куп в интернет-магазин что-нибудь ваз красн-бел-син"
+	if result := stemmer.NormalizeText(text); result != expected {
+		t.Errorf("Not equal: %s != %s", expected, result)
+	}
+	if base := stemmer.GetWordBase("интернет-магазины"); base != "интернет-магазин" {
+		t.Errorf("Not equal: интернет-магазин != %s", base)
+	}
+	if base := string(stemmer.StemAppend(nil, "интернет-магазины")); base != "интернет-магазин" {
+		t.Errorf("Not equal: интернет-магазин != %s", base)
+	}
+}
+
+func TestCompoundStemPartsOutput(t *testing.T) {
+	// The parts are stemmed without the output transformation, which applies once to the whole word.
+	wrap := func(base string) string {
+		return "<" + base + ">"
+	}
+	stemmer := New(WithHyphenatedWords(true), WithOutputTransform(wrap), WithCaseHandling(CasePreserve))
+
+	testWords := map[string]string{
+		"интернет-магазины" : "<интернет-магазин>",
+		"Красно-Белые"      : "<Красн-Бел>",
+		"кого-то"           : "<ког-то>",
+	}
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+		if testBase := stemmer.GetCompoundBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+}
+
+func TestWithHyphenatedWords(t *testing.T) {
+	text := "-интернет-магазины- и вагон-рестораны"
+	testSettings := map[bool]string{
+		true  : "интернет-магазин и вагон-рестора",
+		false : "интернет магазин и вагон рестора",
+	}
+
+	for enabled, expected := range testSettings {
+		if result := New(WithHyphenatedWords(enabled)).NormalizeText(text); result != expected {
+			t.Errorf("Not equal: %s != %s", expected, result)
+		}
+	}
+
+	expected := "-интернет-магазин- и вагон-рестора"
+	if result := New(WithHyphenatedWords(true)).NormalizeTextPreserve(text); result != expected {
+		t.Errorf("Not equal: %s != %s", expected, result)
+	}
+}
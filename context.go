@@ -0,0 +1,64 @@
+package rustemmer
+
+import (
+	"context"
+	"strings"
+)
+
+// ctxCheckInterval is the number of words stemmed between the checks of the context by the Ctx functions.
+const ctxCheckInterval = 1024
+
+// NormalizeTextCtx returns the same text as NormalizeText, or ctx.Err() if ctx is done before the text is normalized.
+func NormalizeTextCtx(ctx context.Context, text string) (string, error) {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.NormalizeTextCtx(ctx, text)
+}
+
+// StemTokensCtx returns the same bases as StemTokens, or ctx.Err() if ctx is done before the words are stemmed.
+func StemTokensCtx(ctx context.Context, tokens []string) ([]string, error) {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemTokensCtx(ctx, tokens)
+}
+
+// NormalizeTextCtx returns the same text as NormalizeText, checking ctx every thousand or so words,
+// so the normalization of a large text may be cancelled, for instance when the client of a request goes away.
+// If ctx is done, it stops and returns an empty string and ctx.Err().
+func (r *RuStemmer) NormalizeTextCtx(ctx context.Context, text string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	words := r.splitWords(text)
+	if err := r.stemTokensCtx(ctx, words); err != nil {
+		return "", err
+	}
+
+	return strings.Join(words, r.separator), nil
+}
+
+// StemTokensCtx returns the same bases as StemTokens, checking ctx every thousand or so words.
+// If ctx is done, it stops and returns nil and ctx.Err().
+func (r *RuStemmer) StemTokensCtx(ctx context.Context, tokens []string) ([]string, error) {
+	stems := append([]string{}, tokens...)
+	if err := r.stemTokensCtx(ctx, stems); err != nil {
+		return nil, err
+	}
+
+	return stems, nil
+}
+
+// stemTokensCtx replaces the words with their bases in place, returning ctx.Err() if ctx is done first.
+func (r *RuStemmer) stemTokensCtx(ctx context.Context, words []string) error {
+	for k, word := range words {
+		if k % ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		words[k] = r.GetWordBase(word)
+	}
+
+	return ctx.Err()
+}
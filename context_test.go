@@ -0,0 +1,85 @@
+package rustemmer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTextCtx(t *testing.T) {
+	text := "г. Москва, ул. Полярная, д. 31А, стр. 1"
+	result, err := NormalizeTextCtx(context.Background(), text)
+	if err != nil || result != NormalizeText(text) {
+		t.Errorf("Not equal: %s != %s %v", NormalizeText(text), result, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if result, err := NormalizeTextCtx(ctx, strings.Repeat("вагоны стоят ", 10000)); result != "" || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancellation, got %q %v", result, err)
+	}
+}
+
+func TestStemTokensCtx(t *testing.T) {
+	words := []string{"вагоны", "стоят", "", "Windows"}
+	stems, err := StemTokensCtx(context.Background(), words)
+	if expected := StemTokens(words); err != nil || !reflect.DeepEqual(stems, expected) {
+		t.Errorf("Not equal: %v != %v %v", expected, stems, err)
+	}
+	if words[0] != "вагоны" {
+		t.Errorf("Expected the words to be unchanged, got %v", words)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if stems, err := StemTokensCtx(ctx, words); stems != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancellation, got %v %v", stems, err)
+	}
+}
+
+// cancellingObserver cancels a context once the given number of words has been stemmed.
+type cancellingObserver struct {
+	cancel func()
+	after  int
+	words  int
+}
+
+func (o *cancellingObserver) WordStemmed(stemLength int) {
+	o.words++
+	if o.words == o.after {
+		o.cancel()
+	}
+}
+
+func (o *cancellingObserver) StepApplied(step string) {}
+
+func (o *cancellingObserver) CacheLookup(hit bool) {}
+
+func TestNormalizeTextCtxMidRun(t *testing.T) {
+	// The context is cancelled while the text is being normalized, so the stemmer
+	// stops at the next check instead of stemming all 20000 words.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observer := &cancellingObserver{cancel: cancel, after: 1500}
+	stemmer := New(WithStats(observer))
+
+	if result, err := stemmer.NormalizeTextCtx(ctx, strings.Repeat("вагоны стоят ", 10000)); result != "" || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancellation, got %q %v", result, err)
+	}
+	if observer.words != 2 * ctxCheckInterval {
+		t.Errorf("Not equal: %d != %d", 2 * ctxCheckInterval, observer.words)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	observer = &cancellingObserver{cancel: cancel, after: 1500}
+	stemmer = New(WithStats(observer))
+	if stems, err := stemmer.StemTokensCtx(ctx, strings.Fields(strings.Repeat("вагоны стоят ", 10000))); stems != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancellation, got %d bases %v", len(stems), err)
+	}
+	if observer.words != 2 * ctxCheckInterval {
+		t.Errorf("Not equal: %d != %d", 2 * ctxCheckInterval, observer.words)
+	}
+}
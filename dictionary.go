@@ -0,0 +1,150 @@
+package rustemmer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// dictionaryVersion is the version of the format written by StemDict.Save.
+// It must be increased whenever the format changes.
+const dictionaryVersion = 1
+
+// ErrDictionaryVersion is returned by LoadDictionary for dictionaries saved in an unsupported format.
+var ErrDictionaryVersion = errors.New("rustemmer: unsupported dictionary version")
+
+// StemDict is a precomputed mapping from words to their bases.
+// A StemDict is not modified after it is built or loaded, so it is safe for concurrent use.
+type StemDict struct {
+	stems map[string]string
+}
+
+// WithDictionary makes GetWordBase and the functions built on it look words up in the dictionary first,
+// falling back to the algorithm for the words missing from it. Words are looked up exactly as given.
+func WithDictionary(d *StemDict) Option {
+	return func(r *RuStemmer) {
+		r.dictionary = d
+	}
+}
+
+// BuildDictionary returns a dictionary of the bases of the words.
+func BuildDictionary(words []string) *StemDict {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.BuildDictionary(words)
+}
+
+// BuildDictionary returns a dictionary of the bases of the words found by this stemmer.
+func (r *RuStemmer) BuildDictionary(words []string) *StemDict {
+	d := &StemDict{stems: make(map[string]string, len(words))}
+	for _, word := range words {
+		d.stems[word] = r.GetWordBase(word)
+	}
+
+	return d
+}
+
+// Len returns the number of words in the dictionary.
+func (d *StemDict) Len() int {
+	return len(d.stems)
+}
+
+// Lookup returns the base of the word and reports whether the word is in the dictionary.
+func (d *StemDict) Lookup(word string) (string, bool) {
+	base, ok := d.stems[word]
+	return base, ok
+}
+
+// Save writes the dictionary to w in a compact binary format readable by LoadDictionary.
+// The format starts with a version byte followed by the number of words and the words
+// with their bases, all lengths being unsigned varints. The words are sorted, so the output is deterministic.
+func (d *StemDict) Save(w io.Writer) error {
+	words := make([]string, 0, len(d.stems))
+	for word := range d.stems {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	buf := bufio.NewWriter(w)
+	buf.WriteByte(dictionaryVersion)
+	writeUvarint(buf, uint64(len(words)))
+	for _, word := range words {
+		writeString(buf, word)
+		writeString(buf, d.stems[word])
+	}
+
+	return buf.Flush()
+}
+
+// LoadDictionary reads a dictionary written by StemDict.Save.
+func LoadDictionary(r io.Reader) (*StemDict, error) {
+	buf := bufio.NewReader(r)
+	version, err := buf.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != dictionaryVersion {
+		return nil, fmt.Errorf("%w %d", ErrDictionaryVersion, version)
+	}
+
+	count, err := binary.ReadUvarint(buf)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	d := &StemDict{stems: map[string]string{}}
+	for i := uint64(0); i < count; i++ {
+		word, err := readString(buf)
+		if err != nil {
+			return nil, err
+		}
+		base, err := readString(buf)
+		if err != nil {
+			return nil, err
+		}
+		d.stems[word] = base
+	}
+
+	return d, nil
+}
+
+// writeUvarint writes x to w as an unsigned varint. Errors are reported by w.Flush.
+func writeUvarint(w *bufio.Writer, x uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], x)
+	w.Write(buf[:n])
+}
+
+// writeString writes the length of s followed by s itself.
+func writeString(w *bufio.Writer, s string) {
+	writeUvarint(w, uint64(len(s)))
+	w.WriteString(s)
+}
+
+// readString reads a string written by writeString.
+func readString(r *bufio.Reader) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", unexpectedEOF(err)
+	}
+
+	// The string is copied in chunks, so a corrupted length does not allocate a huge buffer up front.
+	var s strings.Builder
+	if _, err := io.CopyN(&s, r, int64(n)); err != nil {
+		return "", unexpectedEOF(err)
+	}
+
+	return s.String(), nil
+}
+
+// unexpectedEOF converts io.EOF into io.ErrUnexpectedEOF, as the data ends in the middle of a dictionary.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
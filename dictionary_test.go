@@ -0,0 +1,85 @@
+package rustemmer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestDictionarySaveLoad(t *testing.T) {
+	words := []string{"вагоны", "организация", "важнейшими", "Windows", ""}
+	// The dictionary is built with a suffix the default stemmer does not remove,
+	// so its bases show whether the algorithm was bypassed.
+	dict := New(WithExtraNounSuffixes([]string{"ация"})).BuildDictionary(words)
+	if dict.Len() != len(words) {
+		t.Errorf("Not equal: %d != %d", len(words), dict.Len())
+	}
+
+	var buf bytes.Buffer
+	if err := dict.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	saved := buf.Bytes()
+	if saved[0] != dictionaryVersion {
+		t.Errorf("Not equal: %d != %d", dictionaryVersion, saved[0])
+	}
+
+	loaded, err := LoadDictionary(bytes.NewReader(saved))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, word := range words {
+		expected, _ := dict.Lookup(word)
+		if base, ok := loaded.Lookup(word); !ok || base != expected {
+			t.Errorf("Not equal: [%s] %s != %s", word, expected, base)
+		}
+	}
+
+	buf.Reset()
+	if err := loaded.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(saved, buf.Bytes()) {
+		t.Error("Expected the dictionary to be saved identically after loading")
+	}
+
+	stemmer := New(WithDictionary(loaded))
+	testWords := map[string]string{
+		"организация" : "организ",
+		"вагоны"      : "вагон",
+		"организации" : "организац",
+		"вагонами"    : "вагон",
+	}
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+	if text := stemmer.NormalizeText("Организация вагоны организации"); text != "Организац вагон организац" {
+		t.Errorf("Not equal: %s != %s", "Организац вагон организац", text)
+	}
+	if dst := stemmer.StemAppend(nil, "организация"); string(dst) != "организ" {
+		t.Errorf("Not equal: %s != %s", "организ", dst)
+	}
+}
+
+func TestLoadDictionaryErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := BuildDictionary([]string{"вагоны"}).Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	saved := buf.Bytes()
+
+	if _, err := LoadDictionary(bytes.NewReader([]byte{dictionaryVersion + 1})); !errors.Is(err, ErrDictionaryVersion) {
+		t.Errorf("Expected ErrDictionaryVersion, got %v", err)
+	}
+	if _, err := LoadDictionary(bytes.NewReader(nil)); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+	for n := 1; n < len(saved); n++ {
+		if _, err := LoadDictionary(bytes.NewReader(saved[:n])); err != io.ErrUnexpectedEOF {
+			t.Errorf("Expected io.ErrUnexpectedEOF for %d bytes, got %v", n, err)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package rustemmer_test
 
 import (
+	"bufio"
 	"fmt"
+	"regexp"
+	"strings"
 	"github.com/liderman/rustemmer"
 )
 
@@ -19,3 +22,36 @@ func ExampleNormalizeText() {
 		rustemmer.NormalizeText(text),
 	)
 }
+
+func ExampleWithTokenizer() {
+	// Keep words joined by hyphens, such as "кто-то", together.
+	hyphenated := regexp.MustCompile("[\\p{L}\\p{M}\\d_]+(?:-[\\p{L}\\p{M}\\d_]+)*")
+	stemmer := rustemmer.New(rustemmer.WithTokenizer(rustemmer.TokenizerFunc(func(text string) [][]int {
+		return hyphenated.FindAllStringIndex(text, -1)
+	})))
+	fmt.Println(stemmer.NormalizeText("Кто-то звонил в интернет-магазины"))
+	// Output: Кто-то звон в интернет-магазин
+}
+
+func ExampleNormalizeTextPreserve() {
+	text := "Важные новости:\n\t«вагоны» — в депо!"
+	fmt.Println(rustemmer.NormalizeTextPreserve(text))
+	// Output:
+	// Важн новост:
+	// 	«вагон» — в деп!
+}
+
+func ExampleScanRussianWords() {
+	scanner := bufio.NewScanner(strings.NewReader("Важные новости: вагоны метро — в депо!"))
+	scanner.Split(rustemmer.ScanRussianWords)
+	for scanner.Scan() {
+		fmt.Println(rustemmer.GetWordBase(scanner.Text()))
+	}
+	// Output:
+	// Важн
+	// новост
+	// вагон
+	// метр
+	// в
+	// деп
+}
@@ -0,0 +1,81 @@
+package rustemmer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// AddException makes the stemmer return the stem for the word instead of applying the algorithm,
+// which helps with irregular words such as "люди". The word is matched after the configured
+// normalization, so with WithCaseFolding it should be lower case.
+// The exceptions are copied when they are changed, so AddException does not affect the clones of the stemmer,
+// which may keep stemming concurrently.
+func (r *RuStemmer) AddException(word, stem string) {
+	r.addExceptions(map[string]string{word: stem})
+}
+
+// WithExceptions makes the stemmer return the given stems for the words, as AddException does.
+// The map is copied, so later changes to it do not affect the stemmer.
+func WithExceptions(exceptions map[string]string) Option {
+	return func(r *RuStemmer) {
+		r.addExceptions(exceptions)
+	}
+}
+
+// addExceptions replaces the exceptions of the stemmer with a copy of them extended with the given ones,
+// so the map is copied once however many exceptions are added.
+func (r *RuStemmer) addExceptions(exceptions map[string]string) {
+	if len(exceptions) == 0 {
+		return
+	}
+
+	merged := make(map[string]string, len(r.exceptions) + len(exceptions))
+	for word, stem := range r.exceptions {
+		merged[word] = stem
+	}
+	for word, stem := range exceptions {
+		merged[word] = stem
+	}
+	r.exceptions = merged
+	r.resetCache()
+}
+
+// LoadExceptions adds the exceptions read from src, as AddException does.
+// The exceptions are either a JSON object mapping words to their stems, or lines of a word
+// and its stem separated by a tab. Empty lines and lines starting with "#" are ignored.
+// If src is malformed, none of its exceptions are added.
+func (r *RuStemmer) LoadExceptions(src io.Reader) error {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		exceptions := map[string]string{}
+		if err := json.Unmarshal(data, &exceptions); err != nil {
+			return fmt.Errorf("rustemmer: invalid exceptions: %w", err)
+		}
+		r.addExceptions(exceptions)
+		return nil
+	}
+
+	exceptions := map[string]string{}
+	for k, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			return fmt.Errorf("rustemmer: invalid exception on line %d: %q", k + 1, line)
+		}
+		exceptions[fields[0]] = fields[1]
+	}
+	r.addExceptions(exceptions)
+
+	return nil
+}
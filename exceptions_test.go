@@ -0,0 +1,83 @@
+package rustemmer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddException(t *testing.T) {
+	stemmer := New(WithCache(10))
+	if base := stemmer.GetWordBase("люди"); base != "люд" {
+		t.Errorf("Not equal: %s != %s", "люд", base)
+	}
+
+	stemmer.AddException("люди", "человек")
+	stemmer.AddException("людьми", "человек")
+	testWords := map[string]string{
+		"люди"   : "человек",
+		"людьми" : "человек",
+		"Люди"   : "Люд",
+		"вагоны" : "вагон",
+	}
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+	if dst := stemmer.StemAppend(nil, "люди"); string(dst) != "человек" {
+		t.Errorf("Not equal: %s != %s", "человек", dst)
+	}
+
+	stemmer = New(WithCaseFolding())
+	stemmer.AddException("люди", "человек")
+	if text := stemmer.NormalizeText("Люди и людьми"); text != "человек и людьм" {
+		t.Errorf("Not equal: %s != %s", "человек и людьм", text)
+	}
+
+	if base := GetWordBase("люди"); base != "люд" {
+		t.Errorf("Not equal: %s != %s", "люд", base)
+	}
+}
+
+func TestWithExceptions(t *testing.T) {
+	exceptions := map[string]string{"люди" : "человек"}
+	stemmer := New(WithExceptions(exceptions), WithYoNormalization(), WithStopWords([]string{"и"}), WithOutputSeparator("|"))
+	exceptions["людьми"] = "человек"
+
+	if text := stemmer.NormalizeText("люди и людьми"); text != "человек|людьм" {
+		t.Errorf("Not equal: %s != %s", "человек|людьм", text)
+	}
+}
+
+func TestLoadExceptions(t *testing.T) {
+	testSources := []string{
+		"# irregular words\nлюди\tчеловек\n\nдети\tребенок\n",
+		`{"люди": "человек", "дети": "ребенок"}`,
+		"  \n{\n  \"люди\": \"человек\",\n  \"дети\": \"ребенок\"\n}\n",
+	}
+
+	for _, source := range testSources {
+		stemmer := New()
+		if err := stemmer.LoadExceptions(strings.NewReader(source)); err != nil {
+			t.Fatal(err)
+		}
+		if text := stemmer.NormalizeText("люди, дети и вагоны"); text != "человек ребенок и вагон" {
+			t.Errorf("Not equal: %s != %s", "человек ребенок и вагон", text)
+		}
+	}
+
+	if err := New().LoadExceptions(strings.NewReader("")); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	testInvalid := map[string]string{
+		"люди\tчеловек\nдети ребенок\n" : "rustemmer: invalid exception on line 2: \"дети ребенок\"",
+		`{"люди": 1}`                    : "rustemmer: invalid exceptions: json: ",
+		`{"люди": "человек"`             : "rustemmer: invalid exceptions: ",
+	}
+	for source, expected := range testInvalid {
+		if err := New().LoadExceptions(strings.NewReader(source)); err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Errorf("Not equal: %s != %v", expected, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package rustemmer
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// inflectionEndings are the endings of the cases of nouns and adjectives and of the conjugations and gerunds
+// of verbs, hard and soft, appended to a stem by Expand.
+var inflectionEndings = []string{
+	// Nouns
+	"", "а", "у", "ом", "е", "ы", "ов", "ам", "ами", "ах", "о", "ой", "ою",
+	"ь", "я", "ю", "ем", "и", "ей", "ям", "ями", "ях", "ью", "ия", "ий", "ии", "ию", "ие", "ием", "ией", "иям", "иями", "иях",
+	// Adjectives
+	"ый", "ая", "ое", "ые", "ого", "ому", "ым", "ую", "ых", "ыми",
+	"яя", "ее", "его", "ему", "им", "юю", "их", "ими", "ею",
+	// Verbs
+	"ть", "ешь", "ет", "ете", "ут", "ют", "ишь", "ит", "ите", "ат", "ят", "л", "ла", "ло", "ли", "й", "йте",
+	"в", "вши", "вшись",
+}
+
+// verbEndings are the endings of the conjugations of verbs, followed in Expand by a reflexive ending.
+var verbEndings = []string{
+	"ть", "ю", "у", "ешь", "ет", "ем", "ете", "ут", "ют", "ишь", "ит", "им", "ите", "ат", "ят",
+	"л", "ла", "ло", "ли", "й", "йте",
+}
+
+// Expand returns the word forms with the base stem.
+func Expand(stem string) []string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.Expand(stem)
+}
+
+// Expand returns the word forms the stemmer reduces to the base stem, in alphabetical order, for searching
+// systems that cannot stem at index time, such as LIKE or OR queries against a legacy full-text search.
+// The forms are the stem followed by the endings of the cases of nouns and adjectives and the conjugations
+// of verbs, reflexive ones included, that the suffix tables remove again. As the part of speech of a stem
+// is not known, not all of them are words of the language: "вагон" gives "вагона" and "вагонами" along with
+// "вагоный", which matches nothing in a query. Endings the spelling rules do not allow, such as "ы" after "к",
+// are not appended. The stem itself is included if it is its own base.
+// The slice is empty, but not nil, if no form has the base.
+func (r *RuStemmer) Expand(stem string) []string {
+	forms := []string{}
+	stem = toLower(stem)
+	if stem == "" {
+		return forms
+	}
+	last, _ := utf8.DecodeLastRuneInString(stem)
+
+	candidates := make([]string, 0, len(inflectionEndings) + len(verbEndings))
+	candidates = append(candidates, inflectionEndings...)
+	for _, ending := range verbEndings {
+		candidates = append(candidates, ending + reflexiveEnding(ending))
+	}
+
+	seen := map[string]bool{}
+	for _, ending := range candidates {
+		first, _ := utf8.DecodeRuneInString(ending)
+		if ending != "" && !isSpelledAfter(last, first) {
+			continue
+		}
+		form := stem + ending
+		if !seen[form] && r.wordBase(form) == stem {
+			seen[form] = true
+			forms = append(forms, form)
+		}
+	}
+	sort.Strings(forms)
+
+	return forms
+}
+
+// reflexiveEnding returns the reflexive ending following the verb ending: "сь" after a vowel and "ся" otherwise.
+func reflexiveEnding(ending string) string {
+	last, _ := utf8.DecodeLastRuneInString(ending)
+	if strings.ContainsRune(VOWEL, last) {
+		return "сь"
+	}
+
+	return "ся"
+}
+
+// isSpelledAfter reports whether the spelling rules allow the letter next after the last letter of a stem.
+// After a vowel, "а", "о", "у", "ы", "э" and "ь" are not written; after a consonant, "й" is not, nor are "ы", "я"
+// and "ю" after "г", "к", "х", "ж", "ш", "ч" and "щ", and "я" and "ю" after "ц".
+func isSpelledAfter(last, next rune) bool {
+	switch {
+	case strings.ContainsRune(VOWEL, last):
+		return !strings.ContainsRune("аоуыэь", next)
+	case next == 'й':
+		return false
+	case strings.ContainsRune("гкхжшчщ", last):
+		return next != 'ы' && next != 'я' && next != 'ю'
+	case last == 'ц':
+		return next != 'я' && next != 'ю'
+	}
+
+	return true
+}
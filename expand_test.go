@@ -0,0 +1,57 @@
+package rustemmer
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	testStems := []struct {
+		stem     string
+		included []string
+		excluded []string
+	}{
+		{"вагон", []string{"вагон", "вагона", "вагону", "вагоном", "вагоне", "вагоны", "вагонов", "вагонами"}, []string{"вагонйся", "вагонв"}},
+		{"Ваз", []string{"ваза", "вазы", "вазе", "вазу", "вазой", "вазам"}, []string{"Ваза"}},
+		{"умыва", []string{"умываю", "умывает", "умывают", "умывал", "умывать", "умывалась", "умываются", "умывавшись"}, []string{"умываа", "умываь"}},
+		{"сто", []string{"стою", "стоит", "стоят", "стоится"}, []string{"стоо", "стоы"}},
+		{"рук", []string{"рука", "руки", "руке", "руку", "рукой", "руками"}, []string{"рукы", "рукя"}},
+	}
+
+	for _, test := range testStems {
+		forms := Expand(test.stem)
+		set := stringSet(forms)
+		for _, form := range test.included {
+			if !set[form] {
+				t.Errorf("Expected %s in the forms of %s: %q", form, test.stem, forms)
+			}
+		}
+		for _, form := range test.excluded {
+			if set[form] {
+				t.Errorf("Unexpected %s in the forms of %s", form, test.stem)
+			}
+		}
+		if !sort.StringsAreSorted(forms) {
+			t.Errorf("Expected sorted forms: %q", forms)
+		}
+		for _, form := range forms {
+			if base := GetWordBase(form); base != toLower(test.stem) {
+				t.Errorf("Not equal: %s != %s", toLower(test.stem), base)
+			}
+		}
+	}
+
+	if forms := Expand(""); !reflect.DeepEqual(forms, []string{}) {
+		t.Errorf("Expected no forms, got %q", forms)
+	}
+}
+
+func TestExpandExceptions(t *testing.T) {
+	stemmer := New(WithExceptions(map[string]string{"столы": "столы"}))
+	for _, form := range stemmer.Expand("стол") {
+		if form == "столы" {
+			t.Errorf("Unexpected %s in the forms of стол", form)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package htmlstem normalizes the Russian text of HTML documents, keeping their markup.
+//
+// Only the words of text nodes are replaced with their bases, in place, as rustemmer.NormalizeTextPreserve does.
+// Tags, attributes, comments and character references such as "&nbsp;" are written exactly as they were read,
+// and the contents of script and style elements are not changed.
+package htmlstem
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+
+	"github.com/liderman/rustemmer"
+	"golang.org/x/net/html"
+)
+
+// entityRegexp matches a character reference, which is kept as it is.
+var entityRegexp = regexp.MustCompile("&(?:#[0-9]+|#[xX][0-9a-fA-F]+|[A-Za-z][A-Za-z0-9]*);?")
+
+// NormalizeHTML reads an HTML document from src and writes it to dst with the words of its text
+// replaced with their bases, using the stemmers of rustemmer.Pool.
+func NormalizeHTML(dst io.Writer, src io.Reader) error {
+	r, release := rustemmer.GetPooled()
+	defer release()
+	return NormalizeHTMLWith(r, dst, src)
+}
+
+// NormalizeHTMLWith reads an HTML document from src and writes it to dst with the words of its text
+// replaced with their bases found by the stemmer. The document is processed one token at a time,
+// so it is not kept in memory as a whole.
+func NormalizeHTMLWith(stemmer *rustemmer.RuStemmer, dst io.Writer, src io.Reader) error {
+	z := html.NewTokenizer(src)
+	buf := bufio.NewWriter(dst)
+
+	rawText := false
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); err != io.EOF {
+				return err
+			}
+			return buf.Flush()
+		}
+
+		raw := z.Raw()
+		var err error
+		if tt == html.TextToken && !rawText {
+			_, err = buf.WriteString(normalizeText(stemmer, string(raw)))
+		} else {
+			_, err = buf.Write(raw)
+		}
+		if err != nil {
+			return err
+		}
+
+		// The tag name is read after the raw token is written, as reading it lowers its case in place.
+		switch tt {
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			rawText = isRawTextElement(string(name))
+		case html.EndTagToken:
+			rawText = false
+		}
+	}
+}
+
+// normalizeText returns the raw text of a text node with its words replaced with their bases,
+// keeping the character references.
+func normalizeText(stemmer *rustemmer.RuStemmer, text string) string {
+	indexes := entityRegexp.FindAllStringIndex(text, -1)
+	if indexes == nil {
+		return stemmer.NormalizeTextPreserve(text)
+	}
+
+	ret := make([]byte, 0, len(text))
+	last := 0
+	for _, loc := range indexes {
+		ret = append(ret, stemmer.NormalizeTextPreserve(text[last:loc[0]])...)
+		ret = append(ret, text[loc[0]:loc[1]]...)
+		last = loc[1]
+	}
+	ret = append(ret, stemmer.NormalizeTextPreserve(text[last:])...)
+
+	return string(ret)
+}
+
+// isRawTextElement reports whether the contents of the element are not text to be stemmed.
+func isRawTextElement(name string) bool {
+	return name == "script" || name == "style"
+}
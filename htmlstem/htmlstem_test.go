@@ -0,0 +1,45 @@
+package htmlstem
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/liderman/rustemmer"
+)
+
+func TestNormalizeHTML(t *testing.T) {
+	testDocuments := map[string]string{
+		"" : "",
+		"Вагоны стоят" : "Вагон сто",
+		"<p class=\"Новости\">Важные <b>новости</b>:&nbsp;вагоны&#160;стоят&laquo;депо&raquo;</p>" :
+			"<p class=\"Новости\">Важн <b>новост</b>:&nbsp;вагон&#160;сто&laquo;деп&raquo;</p>",
+		"<!DOCTYPE html><HTML><!-- вагоны --><TITLE>Вагоны</TITLE></HTML>" :
+			"<!DOCTYPE html><HTML><!-- вагоны --><TITLE>Вагон</TITLE></HTML>",
+		"<script>var вагоны = \"вагоны\";</script><style>.вагоны {}</style><p>вагоны</p>" :
+			"<script>var вагоны = \"вагоны\";</script><style>.вагоны {}</style><p>вагон</p>",
+		"<img alt='вагоны' src=x.png/>вагоны<br>депо" : "<img alt='вагоны' src=x.png/>вагон<br>деп",
+	}
+
+	for document, expected := range testDocuments {
+		var buf bytes.Buffer
+		if err := NormalizeHTML(&buf, strings.NewReader(document)); err != nil || buf.String() != expected {
+			t.Errorf("Not equal: %s != %s %v", expected, buf.String(), err)
+		}
+	}
+
+	var buf bytes.Buffer
+	stemmer := rustemmer.New(rustemmer.WithCaseFolding())
+	if err := NormalizeHTMLWith(stemmer, &buf, iotest.OneByteReader(strings.NewReader("<P>Вагоны&amp;Депо</P>"))); err != nil || buf.String() != "<P>вагон&amp;деп</P>" {
+		t.Errorf("Not equal: <P>вагон&amp;деп</P> != %s %v", buf.String(), err)
+	}
+}
+
+func TestNormalizeHTMLErrors(t *testing.T) {
+	errRead := iotest.ErrTimeout
+	var buf bytes.Buffer
+	if err := NormalizeHTML(&buf, iotest.TimeoutReader(strings.NewReader("<p>вагоны</p>"))); err != errRead {
+		t.Errorf("Not equal: %v != %v", errRead, err)
+	}
+}
@@ -0,0 +1,86 @@
+package rustemmer
+
+import (
+	"sync"
+)
+
+// InvertedIndex maps the bases of the words of documents to the IDs of the documents containing them.
+// It is safe for concurrent use.
+type InvertedIndex struct {
+	mu       sync.RWMutex
+	stemmer  *RuStemmer
+	postings map[string][]string
+}
+
+// NewInvertedIndex creates an empty InvertedIndex that stems documents and queries
+// with a stemmer configured with the given options.
+func NewInvertedIndex(opts ...Option) *InvertedIndex {
+	return &InvertedIndex{
+		stemmer:  New(opts...),
+		postings: map[string][]string{},
+	}
+}
+
+// AddDocument adds the document with the given ID and text to the index.
+// Every document should be added once; IDs are kept in the order the documents were added.
+func (idx *InvertedIndex) AddDocument(id string, text string) {
+	stems := idx.stems(text)
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, stem := range stems {
+		ids := idx.postings[stem]
+		if len(ids) > 0 && ids[len(ids) - 1] == id {
+			continue
+		}
+		idx.postings[stem] = append(ids, id)
+	}
+}
+
+// Search returns the IDs of the documents that contain all the words of the query,
+// comparing the words by their bases. The IDs are in the order the documents were added.
+func (idx *InvertedIndex) Search(query string) []string {
+	stems := idx.stems(query)
+	if len(stems) == 0 {
+		return []string{}
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	ret := append([]string{}, idx.postings[stems[0]]...)
+	for _, stem := range stems[1:] {
+		found := map[string]bool{}
+		for _, id := range idx.postings[stem] {
+			found[id] = true
+		}
+
+		matched := ret[:0]
+		for _, id := range ret {
+			if found[id] {
+				matched = append(matched, id)
+			}
+		}
+		ret = matched
+	}
+
+	return ret
+}
+
+// stems returns the unique bases of the words of the text in order of their first occurrence.
+// Every call uses its own clone of the stemmer, so texts can be stemmed concurrently.
+func (idx *InvertedIndex) stems(text string) []string {
+	stemmer := idx.stemmer.Clone()
+
+	ret := []string{}
+	seen := map[string]bool{}
+	for _, word := range stemmer.splitWords(text) {
+		stem := stemmer.GetWordBase(word)
+		if !seen[stem] {
+			seen[stem] = true
+			ret = append(ret, stem)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,50 @@
+package rustemmer
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestInvertedIndex(t *testing.T) {
+	idx := NewInvertedIndex(WithCaseFolding())
+	idx.AddDocument("1", "Важная новость: в вагоне метро заклинило вал")
+	idx.AddDocument("2", "Важные новости из вагонов")
+	idx.AddDocument("3", "Вазы и вальсы")
+
+	testQueries := map[string][]string{
+		"важная"         : {"1", "2"},
+		"Новостями"      : {"1", "2"},
+		"вагоны новости" : {"1", "2"},
+		"вагон вал"      : {"1"},
+		"вазы вагон"     : {},
+		"самолет"        : {},
+		""               : {},
+	}
+
+	for query, expected := range testQueries {
+		if result := idx.Search(query); !reflect.DeepEqual(expected, result) {
+			t.Errorf("Not equal: [%s] %v != %v", query, expected, result)
+		}
+	}
+}
+
+func TestInvertedIndexConcurrent(t *testing.T) {
+	idx := NewInvertedIndex()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			idx.AddDocument(fmt.Sprint(i), "важные новости")
+			idx.Search("новость")
+		}(i)
+	}
+	wg.Wait()
+
+	if result := idx.Search("важная новость"); len(result) != 8 {
+		t.Errorf("Expected 8 documents, got %v", result)
+	}
+}
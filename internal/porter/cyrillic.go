@@ -0,0 +1,34 @@
+package porter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// HasCyrillic reports whether the word contains at least one Cyrillic letter.
+func HasCyrillic(word string) bool {
+	return strings.IndexFunc(word, isCyrillic) >= 0
+}
+
+// IsNotCyrillic reports whether the character is neither a Cyrillic letter
+// nor a combining mark, which belongs to the preceding letter.
+func IsNotCyrillic(char rune) bool {
+	return !isCyrillic(char) && !unicode.Is(unicode.Mn, char)
+}
+
+// SplitCyrillicTail splits the word into its head and the trailing Cyrillic part,
+// which is the part of the word the stemmers remove suffixes from.
+func SplitCyrillicTail(word string) (head, tail string) {
+	i := strings.LastIndexFunc(word, IsNotCyrillic)
+	if i < 0 {
+		return "", word
+	}
+
+	_, size := utf8.DecodeRuneInString(word[i:])
+	return word[:i + size], word[i + size:]
+}
+
+func isCyrillic(char rune) bool {
+	return unicode.Is(unicode.Cyrillic, char)
+}
@@ -0,0 +1,44 @@
+// Package porter implements the parts of the Porter stemming algorithm shared by the stemmers
+// of this module: the Cyrillic part of a word, its regions and the matching of suffix tables.
+package porter
+
+import (
+	"strings"
+)
+
+// Regions returns the RV, R1 and R2 regions of the word as rune offsets, as defined by Snowball:
+// RV is the region after the first vowel, R1 is the region after the first non-vowel following a vowel,
+// and R2 is the region of R1 after the first non-vowel following a vowel.
+// An empty region starts at the end of the word.
+func Regions(word []rune, vowels string) (rv, r1, r2 int) {
+	state := 0
+	wordLength := len(word)
+	rv = wordLength
+	r1 = wordLength
+	r2 = wordLength
+	for i := 0; i < wordLength; i++ {
+		char := word[i]
+		switch state {
+			case 0:
+				if strings.ContainsRune(vowels, char) {
+					rv = i + 1
+					state = 1
+				}
+				break
+			case 1:
+				if strings.ContainsRune(vowels, word[i - 1]) && !strings.ContainsRune(vowels, char) {
+					r1 = i + 1
+					state = 2
+				}
+				break
+			case 2:
+				if strings.ContainsRune(vowels, word[i - 1]) && !strings.ContainsRune(vowels, char) {
+					r2 = i + 1
+					return
+				}
+				break
+		}
+	}
+
+	return
+}
@@ -0,0 +1,82 @@
+package porter
+
+import (
+	"strings"
+)
+
+// Trie is a table of suffixes stored as a trie of the reversed suffixes, so the suffixes
+// ending a word are found in a single pass from the end of the word, however long the table is.
+// A Trie is not modified after it is built, so it may be shared by stemmers.
+type Trie struct {
+	suffixes []string
+	// nodes holds the nodes of the trie; nodes[0] is the root, which matches the end of a word.
+	nodes []trieNode
+}
+
+// trieNode is a node of a Trie.
+type trieNode struct {
+	// end reports whether the path from the root to the node spells a whole suffix.
+	end   bool
+	edges []trieEdge
+}
+
+// trieEdge leads to the node of the preceding letter of a suffix.
+type trieEdge struct {
+	char rune
+	next int
+}
+
+// NewTrie returns a trie matching the suffixes.
+func NewTrie(suffixes []string) *Trie {
+	t := &Trie{suffixes: suffixes, nodes: []trieNode{{}}}
+	for _, suffix := range suffixes {
+		runes := []rune(suffix)
+		node := 0
+		for i := len(runes) - 1; i >= 0; i-- {
+			next := t.next(node, runes[i])
+			if next < 0 {
+				next = len(t.nodes)
+				t.nodes = append(t.nodes, trieNode{})
+				t.nodes[node].edges = append(t.nodes[node].edges, trieEdge{runes[i], next})
+			}
+			node = next
+		}
+		t.nodes[node].end = true
+	}
+
+	return t
+}
+
+// Suffixes returns the suffixes the trie was built from, in the same order. The slice must not be modified.
+func (t *Trie) Suffixes() []string {
+	return t.suffixes
+}
+
+// next returns the node following the node by the letter, or -1 if there is none.
+func (t *Trie) next(node int, char rune) int {
+	for _, edge := range t.nodes[node].edges {
+		if edge.char == char {
+			return edge.next
+		}
+	}
+
+	return -1
+}
+
+// Match returns the length in runes of the longest suffix that ends the word, or 0 if there is none.
+// Unless preceding is empty, the suffix must also be preceded by one of its letters, as the endings
+// of the first group of the Porter algorithm, which follow "а" or "я", must be.
+func (t *Trie) Match(word []rune, preceding string) int {
+	n := 0
+	node := 0
+	for i := len(word) - 1; i >= 0; i-- {
+		if node = t.next(node, word[i]); node < 0 {
+			break
+		}
+		if t.nodes[node].end && (preceding == "" || i > 0 && strings.ContainsRune(preceding, word[i - 1])) {
+			n = len(word) - i
+		}
+	}
+
+	return n
+}
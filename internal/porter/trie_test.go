@@ -0,0 +1,68 @@
+package porter
+
+import (
+	"testing"
+)
+
+func TestTrieMatch(t *testing.T) {
+	trie := NewTrie([]string{"ила", "ями", "ла", "а", "и"})
+	testWords := []struct {
+		word      string
+		preceding string
+		expected  int
+	}{
+		{"говорила", "", 3},
+		{"говорила", "ая", 0},
+		{"делала", "ая", 2},
+		{"дела", "ая", 0},
+		{"ла", "", 2},
+		{"ла", "ая", 0},
+		{"конями", "", 3},
+		{"вагон", "", 0},
+		{"", "", 0},
+	}
+
+	for _, test := range testWords {
+		if n := trie.Match([]rune(test.word), test.preceding); n != test.expected {
+			t.Errorf("Not equal: [%s %s] %d != %d", test.word, test.preceding, test.expected, n)
+		}
+	}
+
+	if n := NewTrie(nil).Match([]rune("вагон"), ""); n != 0 {
+		t.Errorf("Not equal: 0 != %d", n)
+	}
+}
+
+func TestRegions(t *testing.T) {
+	testWords := map[string][3]int{
+		"противоестественном" : {3, 4, 6},
+		"вазы"                : {2, 3, 4},
+		"армія"               : {1, 2, 5},
+		"в"                   : {1, 1, 1},
+		""                    : {0, 0, 0},
+	}
+
+	for word, regions := range testWords {
+		rv, r1, r2 := Regions([]rune(word), "аеиоуюяіїє")
+		if rv != regions[0] || r1 != regions[1] || r2 != regions[2] {
+			t.Errorf("Not equal: [%s] %v != %v", word, regions, [3]int{rv, r1, r2})
+		}
+	}
+}
+
+func TestSplitCyrillicTail(t *testing.T) {
+	testWords := map[string][2]string{
+		"вагони"           : {"", "вагони"},
+		"Windows10-вагоны" : {"Windows10-", "вагоны"},
+		"м'ясо"            : {"м'", "ясо"},
+		"вагони2"          : {"вагони2", ""},
+		"и\u0306од"        : {"", "и\u0306од"},
+		""                 : {"", ""},
+	}
+
+	for word, parts := range testWords {
+		if head, tail := SplitCyrillicTail(word); head != parts[0] || tail != parts[1] {
+			t.Errorf("Not equal: [%s] %v != %v", word, parts, [2]string{head, tail})
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package rustemmer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ErrLemmaDictionary is returned by LoadLemmaDictionary for lines it cannot parse.
+var ErrLemmaDictionary = errors.New("rustemmer: invalid lemma dictionary line")
+
+// LemmaDict maps word forms to their lemmas, the dictionary forms of the words, such as "стали" to "стать".
+// Instead of a map, the forms and the lemmas are kept sorted in a few large strings with slices of offsets,
+// so even the millions of forms of a full dictionary take little more memory than their text.
+// A LemmaDict is not modified after it is loaded, so it is safe for concurrent use.
+type LemmaDict struct {
+	forms        string
+	formOffsets  []uint32
+	formLemmas   []uint32
+	lemmas       string
+	lemmaOffsets []uint32
+}
+
+// WithLemmaDictionary makes GetWordBase and the functions built on it return the lemmas of the words
+// found in the dictionary, falling back to stemming for the others. Words are looked up after
+// the configured normalization, case-insensitively, and the lemmas are returned in lower case.
+func WithLemmaDictionary(d *LemmaDict) Option {
+	return func(r *RuStemmer) {
+		r.lemmas = d
+	}
+}
+
+// LoadLemmaDictionary reads a dictionary of lemmas in one of two plain text formats, which may be mixed.
+// In the format of the OpenCorpora dictionary, a line with the number of a lemma starts a group of lines
+// of its forms, the first of which is the lemma, each optionally followed by a tab and its grammemes,
+// and an empty line ends the group. Outside of such groups, every line is a form and its lemma separated by a tab.
+// If a form has several lemmas, the first one is kept.
+func LoadLemmaDictionary(src io.Reader) (*LemmaDict, error) {
+	scanner := bufio.NewScanner(src)
+	var pairs [][2]string
+	lemma, inGroup := "", false
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			lemma, inGroup = "", false
+		case isNumber(line):
+			lemma, inGroup = "", true
+		default:
+			fields := strings.Split(line, "\t")
+			form := toLower(strings.TrimSpace(fields[0]))
+			if inGroup {
+				if lemma == "" {
+					lemma = form
+				}
+				pairs = append(pairs, [2]string{form, lemma})
+				continue
+			}
+			if len(fields) != 2 || strings.TrimSpace(fields[1]) == "" {
+				return nil, fmt.Errorf("%w %d", ErrLemmaDictionary, n)
+			}
+			pairs = append(pairs, [2]string{form, toLower(strings.TrimSpace(fields[1]))})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return newLemmaDict(pairs), nil
+}
+
+// newLemmaDict returns the dictionary of the pairs of forms and lemmas.
+func newLemmaDict(pairs [][2]string) *LemmaDict {
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i][0] < pairs[j][0]
+	})
+
+	d := &LemmaDict{}
+	var forms, lemmas strings.Builder
+	lemmaIndexes := map[string]uint32{}
+	for k, pair := range pairs {
+		if k > 0 && pair[0] == pairs[k - 1][0] {
+			continue
+		}
+
+		index, ok := lemmaIndexes[pair[1]]
+		if !ok {
+			index = uint32(len(d.lemmaOffsets))
+			lemmaIndexes[pair[1]] = index
+			d.lemmaOffsets = append(d.lemmaOffsets, uint32(lemmas.Len()))
+			lemmas.WriteString(pair[1])
+		}
+		d.formOffsets = append(d.formOffsets, uint32(forms.Len()))
+		d.formLemmas = append(d.formLemmas, index)
+		forms.WriteString(pair[0])
+	}
+	d.formOffsets = append(d.formOffsets, uint32(forms.Len()))
+	d.lemmaOffsets = append(d.lemmaOffsets, uint32(lemmas.Len()))
+	d.forms = forms.String()
+	d.lemmas = lemmas.String()
+
+	return d
+}
+
+// Len returns the number of forms in the dictionary.
+func (d *LemmaDict) Len() int {
+	return len(d.formLemmas)
+}
+
+// Lookup returns the lemma of the word, in lower case, and reports whether the word is in the dictionary.
+// The word is looked up case-insensitively.
+func (d *LemmaDict) Lookup(word string) (string, bool) {
+	word = toLower(word)
+	n := d.Len()
+	i := sort.Search(n, func(i int) bool {
+		return d.form(i) >= word
+	})
+	if i == n || d.form(i) != word {
+		return "", false
+	}
+
+	index := d.formLemmas[i]
+	return d.lemmas[d.lemmaOffsets[index]:d.lemmaOffsets[index + 1]], true
+}
+
+// form returns the form with the index i.
+func (d *LemmaDict) form(i int) string {
+	return d.forms[d.formOffsets[i]:d.formOffsets[i + 1]]
+}
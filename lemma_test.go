@@ -0,0 +1,75 @@
+package rustemmer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// lemmaDictionary mixes groups of forms in the OpenCorpora format with pairs of forms and lemmas.
+const lemmaDictionary = `1
+СТАТЬ	INFN,perf,intr
+СТАЛ	VERB,perf,intr masc,sing,past,indc
+СТАЛИ	VERB,perf,intr plur,past,indc
+
+2
+СТАЛЬ	NOUN,inan,femn sing,nomn
+СТАЛИ	NOUN,inan,femn sing,gent
+
+люди	человек
+Людей	человек
+`
+
+func TestLoadLemmaDictionary(t *testing.T) {
+	d, err := LoadLemmaDictionary(strings.NewReader(lemmaDictionary))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Len() != 6 {
+		t.Errorf("Not equal: 6 != %d", d.Len())
+	}
+
+	testWords := map[string]string{
+		"стать" : "стать",
+		"Стал"  : "стать",
+		"стали" : "стать",
+		"сталь" : "сталь",
+		"люди"  : "человек",
+		"ЛЮДЕЙ" : "человек",
+	}
+	for word, lemma := range testWords {
+		if testLemma, ok := d.Lookup(word); !ok || testLemma != lemma {
+			t.Errorf("Not equal: [%s] %s != %s", word, lemma, testLemma)
+		}
+	}
+	for _, word := range []string{"", "ста", "сталью", "я"} {
+		if lemma, ok := d.Lookup(word); ok {
+			t.Errorf("Unexpected lemma %s of %s", lemma, word)
+		}
+	}
+
+	if _, err := LoadLemmaDictionary(strings.NewReader("люди\n")); !errors.Is(err, ErrLemmaDictionary) {
+		t.Errorf("Expected ErrLemmaDictionary, got %v", err)
+	}
+	if d, err := LoadLemmaDictionary(strings.NewReader("")); err != nil || d.Len() != 0 {
+		t.Errorf("Expected an empty dictionary, got %v %v", d, err)
+	}
+}
+
+func TestWithLemmaDictionary(t *testing.T) {
+	d, err := LoadLemmaDictionary(strings.NewReader(lemmaDictionary))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stemmer := New(WithLemmaDictionary(d))
+	if text := stemmer.NormalizeText("Люди стали у вагонов"); text != "человек стать у вагон" {
+		t.Errorf("Not equal: человек стать у вагон != %s", text)
+	}
+	if base := stemmer.StemAppend(nil, "стали"); string(base) != "стать" {
+		t.Errorf("Not equal: стать != %s", base)
+	}
+	if base := New(WithLemmaDictionary(d), WithCaseHandling(CasePreserve)).GetWordBase("Люди"); base != "Человек" {
+		t.Errorf("Not equal: Человек != %s", base)
+	}
+}
@@ -0,0 +1,75 @@
+package rustemmer
+
+// Range is the position of a word in a text.
+type Range struct {
+	// Start and End are byte offsets of the word in the text.
+	Start int
+	End   int
+	// RuneStart and RuneEnd are the offsets of the word in the text counted in runes.
+	RuneStart int
+	RuneEnd   int
+}
+
+// Match reports whether the text contains all the words of the query, comparing the words by their bases.
+func Match(query, text string) bool {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.Match(query, text)
+}
+
+// FindStemMatches returns the positions of the words of the text that have the base of a word of the query.
+func FindStemMatches(query, text string) []Range {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.FindStemMatches(query, text)
+}
+
+// Match reports whether the text contains all the words of the query, comparing the words by their bases,
+// as InvertedIndex.Search does for documents. A query without words matches no text.
+func (r *RuStemmer) Match(query, text string) bool {
+	stems := r.queryStems(query)
+	if len(stems) == 0 {
+		return false
+	}
+
+	r.ForEachStem(text, func(stem string) bool {
+		delete(stems, stem)
+		return len(stems) > 0
+	})
+
+	return len(stems) == 0
+}
+
+// FindStemMatches returns the positions of the words of the text that have the base of any word of the query,
+// in order of appearance, for instance to highlight them. The result is empty, but not nil, if nothing matches.
+func (r *RuStemmer) FindStemMatches(query, text string) []Range {
+	stems := r.queryStems(query)
+
+	ret := []Range{}
+	if len(stems) == 0 {
+		return ret
+	}
+	for _, token := range r.Tokenize(text) {
+		if stems[token.Stem] {
+			ret = append(ret, Range{
+				Start:     token.Start,
+				End:       token.End,
+				RuneStart: token.RuneStart,
+				RuneEnd:   token.RuneEnd,
+			})
+		}
+	}
+
+	return ret
+}
+
+// queryStems returns the set of the bases of the words of the query.
+func (r *RuStemmer) queryStems(query string) map[string]bool {
+	stems := map[string]bool{}
+	r.ForEachStem(query, func(stem string) bool {
+		stems[stem] = true
+		return true
+	})
+
+	return stems
+}
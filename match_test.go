@@ -0,0 +1,62 @@
+package rustemmer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	text := "Новые вагоны метро стоят в депо"
+	testQueries := map[string]bool{
+		"вагон"          : true,
+		"вагонами метро" : true,
+		"депо вагон"     : true,
+		"вагон трамвая"  : false,
+		"ваза"           : false,
+		""               : false,
+		"!"              : false,
+	}
+
+	for query, expected := range testQueries {
+		if result := Match(query, text); result != expected {
+			t.Errorf("Not equal: [%s] %v != %v", query, expected, result)
+		}
+	}
+
+	if Match("Вагон", text) {
+		t.Error("Expected no match of a capitalized query without case folding")
+	}
+	if !New(WithCaseFolding()).Match("ВАГОН", text) {
+		t.Error("Expected a match with case folding")
+	}
+}
+
+func TestFindStemMatches(t *testing.T) {
+	text := "Вагоны в депо: вагон №1, ёлка и вагонетка."
+
+	expected := []Range{
+		{Start: 26, End: 36, RuneStart: 15, RuneEnd: 20},
+	}
+	if result := FindStemMatches("вагон", text); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+
+	expected = []Range{
+		{Start: 0, End: 12, RuneStart: 0, RuneEnd: 6},
+		{Start: 26, End: 36, RuneStart: 15, RuneEnd: 20},
+		{Start: 43, End: 51, RuneStart: 25, RuneEnd: 29},
+	}
+	result := New(WithCaseFolding()).FindStemMatches("вагонам ёлки", text)
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+	for _, match := range result {
+		if word := text[match.Start:match.End]; word != "Вагоны" && word != "вагон" && word != "ёлка" {
+			t.Errorf("Unexpected match %s", word)
+		}
+	}
+
+	if result := FindStemMatches("", text); result == nil || len(result) != 0 {
+		t.Errorf("Expected no matches, got %v", result)
+	}
+}
@@ -0,0 +1,31 @@
+package rustemmer
+
+import (
+	"strings"
+)
+
+// StemNGrams returns the sequences of n consecutive bases of the words of the text.
+func StemNGrams(text string, n int) []string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemNGrams(text, n)
+}
+
+// StemNGrams returns the sequences of n consecutive bases of the words of the text, known as shingles,
+// in order of appearance, each with its bases separated by a space or the separator set with WithOutputSeparator.
+// Stop words are skipped before the sequences are formed, so with WithRussianStopWords
+// "вагоны стоят в депо" gives "вагон сто" and "сто деп" for n = 2.
+// A text with fewer than n words, or a non-positive n, gives no sequences.
+func (r *RuStemmer) StemNGrams(text string, n int) []string {
+	ngrams := []string{}
+	if n <= 0 {
+		return ngrams
+	}
+
+	stems := r.NormalizeWords(text)
+	for k := 0; k + n <= len(stems); k++ {
+		ngrams = append(ngrams, strings.Join(stems[k:k + n], r.separator))
+	}
+
+	return ngrams
+}
@@ -0,0 +1,33 @@
+package rustemmer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStemNGrams(t *testing.T) {
+	testTexts := []struct {
+		text     string
+		n        int
+		expected []string
+	}{
+		{"Вагоны стоят в депо", 1, []string{"Вагон", "сто", "в", "деп"}},
+		{"Вагоны стоят в депо", 2, []string{"Вагон сто", "сто в", "в деп"}},
+		{"Вагоны стоят в депо", 4, []string{"Вагон сто в деп"}},
+		{"Вагоны стоят в депо", 5, []string{}},
+		{"Вагоны стоят в депо", 0, []string{}},
+		{"", 2, []string{}},
+	}
+
+	for _, test := range testTexts {
+		if ngrams := StemNGrams(test.text, test.n); !reflect.DeepEqual(ngrams, test.expected) {
+			t.Errorf("Not equal: %q != %q", test.expected, ngrams)
+		}
+	}
+
+	stemmer := New(WithRussianStopWords(), WithOutputSeparator("_"))
+	expected := []string{"вагон_сто", "сто_деп"}
+	if ngrams := stemmer.StemNGrams("вагоны стоят в депо", 2); !reflect.DeepEqual(ngrams, expected) {
+		t.Errorf("Not equal: %q != %q", expected, ngrams)
+	}
+}
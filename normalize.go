@@ -0,0 +1,157 @@
+package rustemmer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	combiningBreve     = '\u0306'
+	combiningDiaeresis = '\u0308'
+)
+
+// WithUnicodeNormalization enables or disables the normalization of words to the Unicode NFC form
+// before stemming, which composes decomposed letters, such as "и" followed by a combining breve, into
+// precomposed ones. It is enabled by default, as suffixes are matched against precomposed letters.
+func WithUnicodeNormalization(enabled bool) Option {
+	return func(r *RuStemmer) {
+		r.unicodeNormalization = enabled
+	}
+}
+
+// WithDiacriticStripping removes diacritics, such as the stress marks (U+0301) used in dictionaries
+// and textbooks, from words before stemming, so "молоко\u0301" and "молоко" share a base. The words are
+// decomposed to the NFD form, their nonspacing marks are removed and the rest is composed to the NFC form again,
+// so precomposed letters with stress marks, such as "ѐ", lose them too; words without such marks are left as they are.
+// The breve and the diaeresis are kept, as they tell apart letters such as "й", "ё", "ў" and "ї".
+func WithDiacriticStripping() Option {
+	return func(r *RuStemmer) {
+		r.diacriticStripping = true
+	}
+}
+
+// historicalReplacer replaces the letters abolished by the reform of 1918 with their modern counterparts.
+var historicalReplacer = strings.NewReplacer(
+	"ѣ", "е", "Ѣ", "Е",
+	"і", "и", "І", "И",
+	"ѳ", "ф", "Ѳ", "Ф",
+	"ѵ", "и", "Ѵ", "И",
+)
+
+// WithHistoricalOrthography converts words written in the pre-reform orthography to the modern one
+// before stemming: "ѣ" becomes "е", "і" and "ѵ" become "и", "ѳ" becomes "ф", and the hard sign ending
+// a word is removed, so "хлѣбъ" and "хлеб" share a base. Note that "і" is also a letter of Ukrainian and Belarusian.
+func WithHistoricalOrthography() Option {
+	return func(r *RuStemmer) {
+		r.historicalOrthography = true
+	}
+}
+
+// modernizeOrthography converts the word from the pre-reform orthography to the modern one.
+func modernizeOrthography(word string) string {
+	word = historicalReplacer.Replace(word)
+	for _, hardSign := range []string{"ъ", "Ъ"} {
+		if len(word) > len(hardSign) && strings.HasSuffix(word, hardSign) {
+			return word[:len(word) - len(hardSign)]
+		}
+	}
+
+	return word
+}
+
+// stripDiacritics removes the nonspacing marks other than the breve and the diaeresis from the word,
+// returning it in the NFC form. Invalid UTF-8 sequences are kept as they are.
+func stripDiacritics(word string) string {
+	if !hasStrippedMarks(word) {
+		return word
+	}
+
+	word = norm.NFD.String(word)
+	var buf strings.Builder
+	buf.Grow(len(word))
+	for i := 0; i < len(word); {
+		char, size := utf8.DecodeRuneInString(word[i:])
+		if !isStrippedMark(char) {
+			buf.WriteString(word[i:i + size])
+		}
+		i += size
+	}
+
+	return norm.NFC.String(buf.String())
+}
+
+// hasStrippedMarks reports whether the word holds a mark removed by stripDiacritics,
+// either as a combining character or as a part of a precomposed letter.
+func hasStrippedMarks(word string) bool {
+	for i := 0; i < len(word); {
+		props := norm.NFD.PropertiesString(word[i:])
+		for decomposition := props.Decomposition(); len(decomposition) > 0; {
+			char, size := utf8.DecodeRune(decomposition)
+			if isStrippedMark(char) {
+				return true
+			}
+			decomposition = decomposition[size:]
+		}
+		char, size := utf8.DecodeRuneInString(word[i:])
+		if isStrippedMark(char) {
+			return true
+		}
+		i += size
+	}
+
+	return false
+}
+
+// isStrippedMark reports whether the character is a nonspacing mark removed by stripDiacritics.
+func isStrippedMark(char rune) bool {
+	return char != combiningBreve && char != combiningDiaeresis && unicode.Is(unicode.Mn, char)
+}
+
+// composeNFC returns the word in Unicode normalization form C. NFC decomposes a few characters excluded
+// from composition, such as U+0344, into several, so words it would lengthen are kept as they are,
+// and the normalization never makes a word longer.
+func composeNFC(word string) string {
+	composed := norm.NFC.String(word)
+	if len(composed) > len(word) || utf8.RuneCountInString(composed) > utf8.RuneCountInString(word) {
+		return word
+	}
+
+	return composed
+}
+
+// normalizeUnicode applies the configured Unicode normalization and diacritic stripping to the word.
+func (r *RuStemmer) normalizeUnicode(word string) string {
+	if r.unicodeNormalization {
+		word = composeNFC(word)
+	}
+	if r.diacriticStripping {
+		word = stripDiacritics(word)
+	}
+
+	return word
+}
+
+// toLower returns the word with all letters mapped to lower case.
+// Unlike strings.ToLower, it keeps invalid UTF-8 sequences instead of replacing them with U+FFFD.
+func toLower(word string) string {
+	if utf8.ValidString(word) {
+		return strings.ToLower(word)
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(word))
+	for i := 0; i < len(word); {
+		char, size := utf8.DecodeRuneInString(word[i:])
+		if char == utf8.RuneError && size == 1 {
+			buf.WriteByte(word[i])
+		} else {
+			buf.WriteRune(unicode.ToLower(char))
+		}
+		i += size
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,174 @@
+package rustemmer
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestUnicodeNormalization(t *testing.T) {
+	// Keys spell "й" and "ё" either precomposed or as a letter followed by a combining mark.
+	testWords := map[string]string{
+		"бои\u0306цы"    : "бойц",
+		"бойцы"          : "бойц",
+		"Бои\u0306"      : "Бо",
+		"зеле\u0308ными" : "зелён",
+		"зелёными"       : "зелён",
+	}
+
+	for word, base := range testWords {
+		if testBase := GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%q] %q != %q", word, base, testBase)
+		}
+	}
+
+	if text := NormalizeText("Где бои\u0306цы?"); text != "Где бойц" {
+		t.Errorf("Not equal: %q != %q", "Где бойц", text)
+	}
+
+	// Characters excluded from composition would make the word longer, so it is kept as it is.
+	if base := GetWordBase("вагон\u0344"); base != "вагон\u0344" {
+		t.Errorf("Not equal: %q != %q", "вагон\u0344", base)
+	}
+
+	stemmer := New(WithUnicodeNormalization(false))
+	if base := stemmer.GetWordBase("бои\u0306цы"); base != "бои\u0306ц" {
+		t.Errorf("Not equal: %q != %q", "бои\u0306ц", base)
+	}
+}
+
+func TestUnicodeNormalizationNFD(t *testing.T) {
+	// Every word of the vocabulary decomposed to NFD, as some sources write them, has the base of the word.
+	words := readLines(t, "testdata/snowball/voc.txt")
+	decomposed := 0
+	for _, word := range words {
+		nfd := norm.NFD.String(word)
+		if nfd == word {
+			continue
+		}
+		decomposed++
+		if base, expected := GetWordBase(nfd), GetWordBase(word); base != expected {
+			t.Errorf("Not equal: [%q] %q != %q", nfd, expected, base)
+		}
+	}
+	if decomposed == 0 {
+		t.Error("Expected words with decomposable letters in the vocabulary")
+	}
+
+	text := strings.Join(words[:1000], " ")
+	if result, expected := NormalizeText(norm.NFD.String(text)), NormalizeText(text); result != expected {
+		t.Errorf("Not equal: %q != %q", expected, result)
+	}
+}
+
+func TestUnicodeNormalizationText(t *testing.T) {
+	text := "БОИ\u0306ЦЫ и е\u0308лки"
+
+	if result := New(WithCaseFolding()).NormalizeText(text); result != "бойц и ёлк" {
+		t.Errorf("Not equal: %q != %q", "бойц и ёлк", result)
+	}
+	if result := New(WithYoNormalization()).NormalizeTextPreserve(text); result != "БОЙЦЫ и елк" {
+		t.Errorf("Not equal: %q != %q", "БОЙЦЫ и елк", result)
+	}
+
+	tokens := Tokenize(text)
+	for _, token := range tokens {
+		if original := text[token.Start:token.End]; original != token.Original {
+			t.Errorf("Not equal: %q != %q", token.Original, original)
+		}
+	}
+	if len(tokens) != 3 {
+		t.Errorf("Expected 3 tokens, got %v", tokens)
+	}
+}
+
+func TestWithDiacriticStripping(t *testing.T) {
+	// Keys carry stress marks, as in dictionaries, or spell "й" and "ё" with combining marks.
+	testWords := map[string]string{
+		"молоко\u0301"         : "молок",
+		"зелё\u0301ными"       : "зелён",
+		"зеле\u0308\u0301ными" : "зелён",
+		"бои\u0306цы"          : "бойц",
+		"Дома\u0301"           : "Дом",
+		"с\u0450ла"            : "сел",
+		"в\u045Dлы"            : "вил",
+		"ва\xffзы\u0301"       : "ва\xffзы",
+		"вагоны"               : "вагон",
+	}
+
+	stemmer := New(WithDiacriticStripping())
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%q] %q != %q", word, base, testBase)
+		}
+	}
+
+	if base := New().GetWordBase("молоко\u0301"); base != "молоко\u0301" {
+		t.Errorf("Not equal: %q != %q", "молоко\u0301", base)
+	}
+	// The breve and the diaeresis are kept even without Unicode normalization,
+	// and the letters are composed only in the words that lose other marks.
+	stemmer = New(WithDiacriticStripping(), WithUnicodeNormalization(false))
+	if base := stemmer.GetWordBase("бои\u0306цы"); base != "бои\u0306ц" {
+		t.Errorf("Not equal: %q != %q", "бои\u0306ц", base)
+	}
+	if base := stemmer.GetWordBase("бои\u0306цы\u0301"); base != "бойц" {
+		t.Errorf("Not equal: %q != %q", "бойц", base)
+	}
+	if base := New(WithDiacriticStripping(), WithCaseHandling(CasePreserve)).GetWordBase("МОЛО\u0301КО"); base != "МОЛОК" {
+		t.Errorf("Not equal: %q != %q", "МОЛОК", base)
+	}
+
+	pipeline := NewPipeline().WithUnicodeNormalization().WithDiacriticStripping().WithStemming().Build()
+	if text := pipeline.Process("Бои\u0306цы пи\u0301ли молоко\u0301"); text != "Бойц пил молок" {
+		t.Errorf("Not equal: %q != %q", "Бойц пил молок", text)
+	}
+}
+
+func TestWithHistoricalOrthography(t *testing.T) {
+	stemmer := New(WithHistoricalOrthography(), WithCaseFolding())
+	testPairs := map[string]string{
+		"хлѣбъ"      : "хлеб",
+		"Миръ"       : "мир",
+		"міръ"       : "мир",
+		"ѳеатръ"     : "феатр",
+		"мѵро"       : "миро",
+		"ХЛѢБЪ"      : "хлеб",
+		"объявленіе" : "объявление",
+		"е\u0308лки" : "ёлки",
+	}
+
+	for historical, modern := range testPairs {
+		if base, expected := stemmer.GetWordBase(historical), stemmer.GetWordBase(modern); base != expected {
+			t.Errorf("Not equal: [%s] %s != %s", historical, expected, base)
+		}
+	}
+
+	if base := GetWordBase("хлѣбъ"); base != "хлѣбъ" {
+		t.Errorf("Not equal: %s != %s", "хлѣбъ", base)
+	}
+	if base := stemmer.GetWordBase("ъ"); base != "ъ" {
+		t.Errorf("Not equal: %s != %s", "ъ", base)
+	}
+}
+
+func TestWithHistoricalOrthographyText(t *testing.T) {
+	testTexts := map[string]string{
+		"Всѣ счастливыя семьи похожи другъ на друга" : "все счастливы сем похож друг на друг",
+		"Миръ хижинамъ, война дворцамъ!"             : "мир хижин войн дворц",
+		"Въ лѣсу родилась ёлочка"                    : "в лес род ёлочк",
+	}
+
+	stemmer := New(WithHistoricalOrthography(), WithCaseFolding())
+	for text, expected := range testTexts {
+		if normalized := stemmer.NormalizeText(text); normalized != expected {
+			t.Errorf("Not equal: %s != %s", expected, normalized)
+		}
+	}
+
+	text := "Всѣ счастливыя семьи похожи другъ на друга"
+	if normalized := NormalizeText(text); normalized != "Всѣ счастливы сем похож другъ на друг" {
+		t.Errorf("Not equal: %s != %s", "Всѣ счастливы сем похож другъ на друг", normalized)
+	}
+}
@@ -0,0 +1,77 @@
+package rustemmer
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// NumberPolicy selects what happens to the words of a text containing digits.
+type NumberPolicy int
+
+const (
+	// NumberStem stems the words like any other words, which is the default. Numbers stay unchanged,
+	// as they have no Cyrillic letters, while the Cyrillic tails of words such as "2024года" are stemmed.
+	NumberStem NumberPolicy = iota
+	// NumberKeep passes the words through verbatim.
+	NumberKeep
+	// NumberDrop leaves the words out when a text is split into words, as stop words are.
+	NumberDrop
+	// NumberSplit splits the words at the boundaries between letters and digits, so "2024года"
+	// becomes the words "2024" and "года", which are then handled separately. Numbers are stemmed as with NumberStem.
+	NumberSplit
+)
+
+// WithNumberPolicy sets what happens to numbers, the words consisting of digits only, such as "2024".
+func WithNumberPolicy(policy NumberPolicy) Option {
+	return func(r *RuStemmer) {
+		r.numberPolicy = policy
+	}
+}
+
+// WithAlphanumericPolicy sets what happens to the words mixing letters and digits, such as "31А" and "2024г".
+// With NumberSplit the parts of the words are words of their own, so numbers among them follow WithNumberPolicy.
+func WithAlphanumericPolicy(policy NumberPolicy) Option {
+	return func(r *RuStemmer) {
+		r.alphanumericPolicy = policy
+	}
+}
+
+// isAlphanumeric reports whether the word contains both letters and digits.
+func isAlphanumeric(word string) bool {
+	hasLetter, hasDigit := false, false
+	for _, char := range word {
+		hasLetter = hasLetter || unicode.IsLetter(char)
+		hasDigit = hasDigit || unicode.IsDigit(char)
+	}
+
+	return hasLetter && hasDigit
+}
+
+// isDigitBoundary reports whether there is a boundary between a letter and a digit between the characters.
+// Combining marks belong to the preceding letter.
+func isDigitBoundary(prev, char rune) bool {
+	isLetter := func(char rune) bool {
+		return unicode.IsLetter(char) || unicode.IsMark(char)
+	}
+
+	return unicode.IsDigit(prev) && isLetter(char) || isLetter(prev) && unicode.IsDigit(char)
+}
+
+// splitAlphanumeric returns the byte offsets of the words of the text with the words mixing letters
+// and digits split at the boundaries between them.
+func splitAlphanumeric(text string, indexes [][]int) [][]int {
+	ret := make([][]int, 0, len(indexes))
+	for _, loc := range indexes {
+		start, prev := loc[0], utf8.RuneError
+		for i, char := range text[loc[0]:loc[1]] {
+			if isDigitBoundary(prev, char) {
+				ret = append(ret, []int{start, loc[0] + i})
+				start = loc[0] + i
+			}
+			prev = char
+		}
+		ret = append(ret, []int{start, loc[1]})
+	}
+
+	return ret
+}
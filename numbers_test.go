@@ -0,0 +1,61 @@
+package rustemmer
+
+import (
+	"testing"
+)
+
+func TestNumberPolicy(t *testing.T) {
+	testOptions := []struct {
+		opts     []Option
+		expected string
+	}{
+		{nil, "дом 31А в 2024год 15 квартир"},
+		{[]Option{WithAlphanumericPolicy(NumberKeep)}, "дом 31А в 2024годах 15 квартир"},
+		{[]Option{WithAlphanumericPolicy(NumberDrop)}, "дом в 15 квартир"},
+		{[]Option{WithAlphanumericPolicy(NumberSplit)}, "дом 31 А в 2024 год 15 квартир"},
+		{[]Option{WithNumberPolicy(NumberDrop)}, "дом 31А в 2024год квартир"},
+		{[]Option{WithNumberPolicy(NumberDrop), WithAlphanumericPolicy(NumberSplit)}, "дом А в год квартир"},
+		{[]Option{WithNumberPolicy(NumberDrop), WithAlphanumericPolicy(NumberDrop)}, "дом в квартир"},
+		{[]Option{WithoutNonRussianWords(), WithNumberPolicy(NumberKeep), WithAlphanumericPolicy(NumberSplit)}, "дом 31 А в 2024 год 15 квартир"},
+	}
+
+	for _, test := range testOptions {
+		if text := New(test.opts...).NormalizeText("дом 31А в 2024годах, 15 квартир"); text != test.expected {
+			t.Errorf("Not equal: %s != %s", test.expected, text)
+		}
+	}
+}
+
+func TestNumberPolicySplitTokens(t *testing.T) {
+	text := "Ул. 8Марта, д. 5"
+	tokens := New(WithAlphanumericPolicy(NumberSplit)).Tokenize(text)
+
+	expected := []string{"Ул", "8", "Март", "д", "5"}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Not equal: %d != %d", len(expected), len(tokens))
+	}
+	for k, token := range tokens {
+		if token.Stem != expected[k] || text[token.Start:token.End] != token.Original {
+			t.Errorf("Not equal: %s != %s [%s]", expected[k], token.Stem, text[token.Start:token.End])
+		}
+	}
+	if tokens[2].RuneStart != 5 || tokens[2].RuneEnd != 10 {
+		t.Errorf("Not equal: [5 10] != [%d %d]", tokens[2].RuneStart, tokens[2].RuneEnd)
+	}
+
+	if preserved := New(WithAlphanumericPolicy(NumberSplit)).NormalizeTextPreserve(text); preserved != "Ул. 8Март, д. 5" {
+		t.Errorf("Not equal: Ул. 8Март, д. 5 != %s", preserved)
+	}
+}
+
+func TestNumberPolicyGetWordBase(t *testing.T) {
+	if base := New(WithAlphanumericPolicy(NumberKeep)).GetWordBase("2024годах"); base != "2024годах" {
+		t.Errorf("Not equal: 2024годах != %s", base)
+	}
+	if base := New().GetWordBase("2024годах"); base != "2024год" {
+		t.Errorf("Not equal: 2024год != %s", base)
+	}
+	if base := New(WithNumberPolicy(NumberKeep), WithMinWordLength(5)).GetWordBase("2024"); base != "2024" {
+		t.Errorf("Not equal: 2024 != %s", base)
+	}
+}
@@ -0,0 +1,154 @@
+package rustemmer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Option configures a RuStemmer created with New.
+// Options are applied only when the stemmer is constructed.
+type Option func(*RuStemmer)
+
+var yoReplacer = strings.NewReplacer("ё", "е", "Ё", "Е")
+
+// WithYoNormalization replaces the letter "ё" with "е" before stemming,
+// so that "ёлка" and "елка" share the same base.
+func WithYoNormalization() Option {
+	return func(r *RuStemmer) {
+		r.yoNormalization = true
+	}
+}
+
+// WithCaseFolding converts words to lower case before stemming. It is the same as WithCaseHandling(CaseLower).
+func WithCaseFolding() Option {
+	return WithCaseHandling(CaseLower)
+}
+
+// WithMinWordLength leaves words shorter than n runes unstemmed.
+// Zero, the default, stems words of any length.
+func WithMinWordLength(n int) Option {
+	return func(r *RuStemmer) {
+		r.minWordLength = n
+	}
+}
+
+// DefaultMinStemLength is the minimal length, in runes, of a base left by the Porter steps by default.
+const DefaultMinStemLength = 2
+
+// WithMinStemLength leaves words unstemmed if the Porter steps would leave fewer than n runes of them,
+// so short words such as "ей" are not stripped to a single letter. The default is DefaultMinStemLength;
+// zero disables the check, so that bases follow the algorithm strictly, as WithAlgorithm(AlgorithmSnowball) does.
+func WithMinStemLength(n int) Option {
+	return func(r *RuStemmer) {
+		r.minStemLength = n
+	}
+}
+
+// WithIdempotentStems repeats the Porter steps over the base until they leave it unchanged, so that stemming
+// a base returns it as it is: GetWordBase(GetWordBase(word)) == GetWordBase(word). The Porter algorithm itself
+// is not idempotent, "академии" becomes "академ" and "академ" becomes "акад", so with this option some bases
+// are shorter than those of the reference implementation. Prefixes set with WithPrefixes are still removed once.
+func WithIdempotentStems() Option {
+	return func(r *RuStemmer) {
+		r.idempotentStems = true
+	}
+}
+
+// WithStopWords sets the words that are skipped when a text is split into words.
+// Stop words are matched case-insensitively. They are dropped from the output of
+// NormalizeText and Tokenize and left untouched by NormalizeTextPreserve.
+func WithStopWords(words []string) Option {
+	return func(r *RuStemmer) {
+		r.stopWords = stopWordSet(words)
+	}
+}
+
+// WithoutNonRussianWords drops words without Cyrillic letters, such as Latin words and numbers,
+// from the output of NormalizeText and Tokenize. By default they are kept unchanged.
+func WithoutNonRussianWords() Option {
+	return func(r *RuStemmer) {
+		r.dropNonRussian = true
+	}
+}
+
+// WithNonRussianStemmer makes the stemmer pass words without Cyrillic letters, such as English words,
+// to s instead of returning them unchanged. Words mixing Cyrillic and other letters are still stemmed
+// as Russian words, and numbers kept with WithKeepNumbers are not passed to s.
+// As s is shared by the clones of the stemmer, it should be safe for concurrent use.
+func WithNonRussianStemmer(s WordStemmer) Option {
+	return func(r *RuStemmer) {
+		r.nonRussianStemmer = s
+	}
+}
+
+// WithKeepNumbers makes the stemmer pass numbers, words consisting of digits only, through verbatim.
+// Numbers are then kept even if WithoutNonRussianWords drops other non-Russian words,
+// which matters for addresses and dates. It is the same as WithNumberPolicy(NumberKeep),
+// and WithKeepNumbers(false) is the same as WithNumberPolicy(NumberStem).
+func WithKeepNumbers(keep bool) Option {
+	if keep {
+		return WithNumberPolicy(NumberKeep)
+	}
+
+	return WithNumberPolicy(NumberStem)
+}
+
+// WithTokenizerPattern sets the regular expression used to find words in a text,
+// replacing a tokenizer set with WithTokenizer before it. It panics if the pattern cannot be compiled.
+func WithTokenizerPattern(pattern string) Option {
+	return func(r *RuStemmer) {
+		r.tokenizer = regexpTokenizer{regexp.MustCompile(pattern)}
+	}
+}
+
+// WithOutputSeparator sets the string NormalizeText puts between word bases instead of a single space.
+// Use NormalizeTextPreserve to keep the original punctuation and whitespace instead.
+func WithOutputSeparator(sep string) Option {
+	return func(r *RuStemmer) {
+		r.separator = sep
+	}
+}
+
+// WithOutputTransform makes GetWordBase and the functions built on it, such as NormalizeText,
+// return fn(base) instead of every base, after the case of the word is restored.
+// For instance, translit.GOST.Latin from the translit subpackage emits the bases in Latin letters.
+func WithOutputTransform(fn func(base string) string) Option {
+	return func(r *RuStemmer) {
+		r.outputTransform = fn
+	}
+}
+
+// WithCache enables memoization of up to size word bases.
+// When the cache is full a word that was not used recently is evicted, the least recently added one
+// unless it was looked up since. Large caches are split into shards with their own locks,
+// so the clones sharing a cache rarely contend, and every shard evicts its own words.
+// A size less than or equal to zero disables the cache. Words are cached after the configured
+// normalization, so with case folding or CasePreserve their spellings in any case share an entry.
+// CacheStats and PoolCacheStats report the hits and misses.
+func WithCache(size int) Option {
+	return func(r *RuStemmer) {
+		if size <= 0 {
+			r.cache = nil
+			return
+		}
+		r.cache = newStemCache(size)
+	}
+}
+
+// skipWord reports whether the word is left out when a text is split into words.
+func (r *RuStemmer) skipWord(word string) bool {
+	word = strings.ToLower(word)
+	if r.stopWords[word] || r.yoNormalization && r.stopWords[yoReplacer.Replace(word)] {
+		return true
+	}
+
+	if r.numberPolicy == NumberDrop && isNumber(word) || r.alphanumericPolicy == NumberDrop && isAlphanumeric(word) {
+		return true
+	}
+
+	if r.invalidWordPolicy == InvalidWordSkip && r.isInvalidWord(word) {
+		return true
+	}
+
+	return r.dropNonRussian && !IsRussianWord(word) && !(r.numberPolicy == NumberKeep && isNumber(word))
+}
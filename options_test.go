@@ -0,0 +1,360 @@
+package rustemmer
+
+import (
+	"testing"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+func TestWithYoNormalization(t *testing.T) {
+	stemmer := New(WithYoNormalization())
+	if base := stemmer.GetWordBase("берёзами"); base != "берез" {
+		t.Errorf("Not equal: берез != %s", base)
+	}
+	if base := New().GetWordBase("берёзами"); base != "берёз" {
+		t.Errorf("Not equal: берёз != %s", base)
+	}
+	if text := stemmer.NormalizeText("Берёзы и ёлки"); text != "Берез и елк" {
+		t.Errorf("Not equal: Берез и елк != %s", text)
+	}
+
+	stemmer = New(WithYoNormalization(), WithStopWords([]string{"еще"}))
+	if text := stemmer.NormalizeText("ещё вагоны"); text != "вагон" {
+		t.Errorf("Not equal: вагон != %s", text)
+	}
+}
+
+func TestWithNonRussianStemmer(t *testing.T) {
+	stemmer := New(WithNonRussianStemmer(upperStemmer{}), WithKeepNumbers(true))
+	text := "Купил iPhone за 1000 долларов в shop-магазине"
+	if normalized := stemmer.NormalizeText(text); normalized != "Куп IPHONE за 1000 доллар в SHOP магазин" {
+		t.Errorf("Not equal: %s != %s", "Куп IPHONE за 1000 доллар в SHOP магазин", normalized)
+	}
+	if base := string(stemmer.StemAppend(nil, "iPhone")); base != "IPHONE" {
+		t.Errorf("Not equal: IPHONE != %s", base)
+	}
+	if base := stemmer.GetWordBase("iPhoneы"); base != "iPhoneы" {
+		t.Errorf("Not equal: iPhoneы != %s", base)
+	}
+	if text := New().NormalizeText(text); text != "Куп iPhone за 1000 доллар в shop магазин" {
+		t.Errorf("Not equal: %s != %s", "Куп iPhone за 1000 доллар в shop магазин", text)
+	}
+}
+
+func TestWithCaseFolding(t *testing.T) {
+	stemmer := New(WithCaseFolding())
+	if text := stemmer.NormalizeText("Важная НОВОСТЬ"); text != "важн новост" {
+		t.Errorf("Not equal: важн новост != %s", text)
+	}
+}
+
+func TestWithStopWords(t *testing.T) {
+	stemmer := New(WithStopWords([]string{"в", "НА"}))
+
+	if text := stemmer.NormalizeText("В вагоне на вокзале"); text != "вагон вокзал" {
+		t.Errorf("Not equal: вагон вокзал != %s", text)
+	}
+	if text := stemmer.NormalizeTextPreserve("В вагоне на вокзале"); text != "В вагон на вокзал" {
+		t.Errorf("Not equal: В вагон на вокзал != %s", text)
+	}
+}
+
+func TestWithTokenizerPattern(t *testing.T) {
+	stemmer := New(WithTokenizerPattern("[\\p{L}\\d_-]+"))
+	if text := stemmer.NormalizeText("темно-синий, вазы"); text != "темно-син ваз" {
+		t.Errorf("Not equal: темно-син ваз != %s", text)
+	}
+}
+
+func TestWithCache(t *testing.T) {
+	text := "Важная новость: важные новости, важная новость!"
+	stemmer := New(WithCache(2))
+
+	expected := New().Tokenize(text)
+	for i := 0; i < 3; i++ {
+		if tokens := stemmer.Tokenize(text); !reflect.DeepEqual(expected, tokens) {
+			t.Errorf("Not equal: %v != %v", expected, tokens)
+		}
+	}
+	if size := stemmer.cache.len(); size != 2 {
+		t.Errorf("Expected 2 cached entries, got %d", size)
+	}
+
+	if stemmer := New(WithCache(0)); stemmer.cache != nil {
+		t.Errorf("Expected cache to be disabled")
+	}
+}
+
+func TestWithMinWordLength(t *testing.T) {
+	stemmer := New(WithMinWordLength(4))
+
+	testWords := map[string]string{
+		"ты"    : "ты",
+		"вали"  : "вал",
+		"мои"   : "мои",
+		"вазы"  : "ваз",
+		"ель"   : "ель",
+		"вагон" : "вагон",
+	}
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+
+	if text := stemmer.NormalizeText("Мои вазы в вагоне"); text != "Мои ваз в вагон" {
+		t.Errorf("Not equal: Мои ваз в вагон != %s", text)
+	}
+
+	if base := New().GetWordBase("мои"); base != "мо" {
+		t.Errorf("Not equal: мо != %s", base)
+	}
+
+	text := "Результаты проверки города в DB: ты мои вали"
+	if expected, result := New().NormalizeText(text), New(WithMinWordLength(0)).NormalizeText(text); expected != result {
+		t.Errorf("Not equal: %s != %s", expected, result)
+	}
+}
+
+func TestWithMinStemLength(t *testing.T) {
+	testWords := map[string][2]string{
+		"ей"     : {"ей", "е"},
+		"ою"     : {"ою", "о"},
+		"ая"     : {"ая", "а"},
+		"мои"    : {"мо", "мо"},
+		"вагоны"    : {"вагон", "вагон"},
+		"он"     : {"он", "он"},
+	}
+
+	stemmer, strict := New(), New(WithMinStemLength(0))
+	for word, bases := range testWords {
+		if base := stemmer.GetWordBase(word); base != bases[0] {
+			t.Errorf("Not equal: [%s] %s != %s", word, bases[0], base)
+		}
+		if base := strict.GetWordBase(word); base != bases[1] {
+			t.Errorf("Not equal: [%s] %s != %s", word, bases[1], base)
+		}
+	}
+
+	if base := New(WithMinStemLength(4)).GetWordBase("мои"); base != "мои" {
+		t.Errorf("Not equal: мои != %s", base)
+	}
+	if base, trace := stemmer.GetWordBaseTrace("ей"); base != "ей" || len(trace) != 0 {
+		t.Errorf("Not equal: ей [] != %s %v", base, trace)
+	}
+}
+
+func TestWithIdempotentStems(t *testing.T) {
+	testWords := map[string][2]string{
+		"академии"  : {"академ", "акад"},
+		"академ"    : {"акад", "акад"},
+		"алексеева" : {"алексеев", "алекс"},
+		"работа"    : {"работ", "работ"},
+		"ценнейший" : {"цен", "цен"},
+		"вагоны"    : {"вагон", "вагон"},
+	}
+
+	stemmer, stable := New(), New(WithIdempotentStems())
+	for word, bases := range testWords {
+		if base := stemmer.GetWordBase(word); base != bases[0] {
+			t.Errorf("Not equal: [%s] %s != %s", word, bases[0], base)
+		}
+		if base := stable.GetWordBase(word); base != bases[1] {
+			t.Errorf("Not equal: [%s] %s != %s", word, bases[1], base)
+		}
+		if base := stable.GetWordBase(bases[1]); base != bases[1] {
+			t.Errorf("Not equal: [%s] %s != %s", bases[1], bases[1], base)
+		}
+	}
+
+	// The minimal stem length stops the repeated steps as it stops the first ones.
+	if base := New(WithIdempotentStems(), WithMinStemLength(5)).GetWordBase("академии"); base != "академ" {
+		t.Errorf("Not equal: академ != %s", base)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	defer Configure()
+
+	testOptions := []struct {
+		opts     []Option
+		text     string
+		expected string
+	}{
+		{[]Option{WithYoNormalization()}, "Берёзами", "Берез"},
+		{[]Option{WithCaseFolding()}, "Важная НОВОСТЬ", "важн новост"},
+		{[]Option{WithStopWords([]string{"в"})}, "В вагоне", "вагон"},
+		{[]Option{WithMinWordLength(4)}, "мои вазы", "мои ваз"},
+		{[]Option{WithTokenizerPattern("[\\p{L}-]+")}, "темно-синий", "темно-син"},
+		{[]Option{WithCache(10)}, "Важная новость", "Важн новост"},
+	}
+
+	for _, test := range testOptions {
+		if text := New(test.opts...).NormalizeText(test.text); text != test.expected {
+			t.Errorf("Not equal: %s != %s", test.expected, text)
+		}
+
+		Configure(test.opts...)
+		if text := NormalizeText(test.text); text != test.expected {
+			t.Errorf("Not equal: %s != %s", test.expected, text)
+		}
+
+		Configure()
+		if text, expected := NormalizeText(test.text), New().NormalizeText(test.text); text != expected {
+			t.Errorf("Not equal: %s != %s", expected, text)
+		}
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	defer SetDefault(nil)
+
+	stemmer := New(WithCaseFolding())
+	SetDefault(stemmer)
+	stemmer.AddException("вагоны", "вагоны")
+	if text := NormalizeText("Вагоны стоят"); text != "вагон сто" {
+		t.Errorf("Not equal: вагон сто != %s", text)
+	}
+
+	SetDefault(nil)
+	if text := NormalizeText("Вагоны стоят"); text != "Вагон сто" {
+		t.Errorf("Not equal: Вагон сто != %s", text)
+	}
+
+	// The exceptions of the default stemmer are copied, so adding exceptions to the stemmer
+	// neither changes the default nor races with the package-level functions.
+	seeded := New(WithExceptions(map[string]string{"люди": "человек"}))
+	seeded.AddProtectedWords([]string{"депо"})
+	SetDefault(seeded)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			if text := NormalizeText("люди и вагоны"); text != "человек и вагон" {
+				t.Errorf("Not equal: человек и вагон != %s", text)
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		seeded.AddException("вагоны", "вагоны")
+		seeded.AddProtectedWords([]string{"вагоны"})
+	}
+	<-done
+	if text := NormalizeText("люди и вагоны"); text != "человек и вагон" {
+		t.Errorf("Not equal: человек и вагон != %s", text)
+	}
+	SetDefault(nil)
+
+	var wg sync.WaitGroup
+	for k := 0; k < 4; k++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if base := GetWordBase("вагоны"); base != "вагон" {
+					t.Errorf("Not equal: вагон != %s", base)
+				}
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		SetDefault(New(WithCaseFolding(), WithCache(10)))
+	}
+	wg.Wait()
+}
+
+func TestPerCallOptions(t *testing.T) {
+	Configure(WithCache(10), WithExceptions(map[string]string{"люди": "человек"}))
+	defer Configure()
+
+	testOptions := []struct {
+		opts     []Option
+		text     string
+		expected string
+	}{
+		{[]Option{WithRussianStopWords()}, "Вагоны стоят в депо", "Вагон сто деп"},
+		{[]Option{WithCaseFolding(), WithOutputSeparator("_")}, "Важная НОВОСТЬ", "важн_новост"},
+		{[]Option{WithExceptions(map[string]string{"новость": "новость"})}, "люди и новость", "человек и новость"},
+		{nil, "люди и новость", "человек и новост"},
+	}
+
+	for _, test := range testOptions {
+		if text := NormalizeTextWithOptions(test.text, test.opts...); text != test.expected {
+			t.Errorf("Not equal: %s != %s", test.expected, text)
+		}
+	}
+
+	if base := GetWordBaseWithOptions("НОВОСТИ", WithCaseFolding()); base != "новост" {
+		t.Errorf("Not equal: новост != %s", base)
+	}
+	if base := GetWordBase("НОВОСТИ"); base != "НОВОСТИ" {
+		t.Errorf("Not equal: НОВОСТИ != %s", base)
+	}
+
+	// GetWordBase and NormalizeText keep their type, so they can still be passed as callbacks.
+	for _, fn := range []func(string) string{GetWordBase, NormalizeText} {
+		if base := fn("вагоны"); base != "вагон" {
+			t.Errorf("Not equal: вагон != %s", base)
+		}
+	}
+}
+
+func TestWithoutNonRussianWords(t *testing.T) {
+	text := "Планшет IRU Pad Master B703, 4Гб, Wi-Fi, Android 4.1"
+
+	if result := New(WithoutNonRussianWords()).NormalizeText(text); result != "Планшет 4Гб" {
+		t.Errorf("Not equal: Планшет 4Гб != %s", result)
+	}
+	if result := New().NormalizeText(text); result != "Планшет IRU Pad Master B703 4Гб Wi Fi Android 4 1" {
+		t.Errorf("Not equal: Планшет IRU Pad Master B703 4Гб Wi Fi Android 4 1 != %s", result)
+	}
+}
+
+func TestWithOutputSeparator(t *testing.T) {
+	testSeparators := map[string]string{
+		""   : "гМосквулПолярн",
+		"|"  : "г|Москв|ул|Полярн",
+		", " : "г, Москв, ул, Полярн",
+	}
+
+	for sep, expected := range testSeparators {
+		if text := New(WithOutputSeparator(sep)).NormalizeText("г. Москва, ул. Полярная"); text != expected {
+			t.Errorf("Not equal: %s != %s", expected, text)
+		}
+	}
+}
+
+func TestWithKeepNumbers(t *testing.T) {
+	testOptions := []struct {
+		opts     []Option
+		expected string
+	}{
+		{nil, "дом 31 квартир 5 2024 год"},
+		{[]Option{WithKeepNumbers(false)}, "дом 31 квартир 5 2024 год"},
+		{[]Option{WithKeepNumbers(true)}, "дом 31 квартир 5 2024 год"},
+		{[]Option{WithoutNonRussianWords()}, "дом квартир год"},
+		{[]Option{WithoutNonRussianWords(), WithKeepNumbers(false)}, "дом квартир год"},
+		{[]Option{WithoutNonRussianWords(), WithKeepNumbers(true)}, "дом 31 квартир 5 2024 год"},
+	}
+
+	for _, test := range testOptions {
+		if text := New(test.opts...).NormalizeText("дом 31, квартира 5 (2024 год)"); text != test.expected {
+			t.Errorf("Not equal: %s != %s", test.expected, text)
+		}
+	}
+
+	if base := New(WithKeepNumbers(true), WithMinWordLength(1)).GetWordBase("2024"); base != "2024" {
+		t.Errorf("Not equal: 2024 != %s", base)
+	}
+}
+
+func TestWithOutputTransform(t *testing.T) {
+	stemmer := New(WithOutputTransform(strings.ToUpper), WithCaseHandling(CasePreserve), WithProtectedWords([]string{"ооо"}))
+	if text := stemmer.NormalizeText("Москва, ооо вагоны"); text != "МОСКВ ООО ВАГОН" {
+		t.Errorf("Not equal: МОСКВ ООО ВАГОН != %s", text)
+	}
+	if base, changed := stemmer.GetWordBaseInfo("Москва"); base != "МОСКВ" || !changed {
+		t.Errorf("Not equal: МОСКВ true != %s %v", base, changed)
+	}
+}
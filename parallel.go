@@ -0,0 +1,74 @@
+package rustemmer
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// StemAll returns the bases of the words in the same order, stemming them with one worker per CPU.
+func StemAll(words []string) []string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemAll(words)
+}
+
+// NormalizeTextParallel returns the same text as NormalizeText, stemming the words with the given number of workers.
+// With workers less than or equal to 1 the text is normalized sequentially.
+func NormalizeTextParallel(text string, workers int) string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.NormalizeTextParallel(text, workers)
+}
+
+// NormalizeTextParallel returns the same text as NormalizeText, stemming the words with the given number of workers.
+// Every worker uses its own clone of r. With workers less than or equal to 1 the text is normalized sequentially.
+func (r *RuStemmer) NormalizeTextParallel(text string, workers int) string {
+	if workers <= 1 {
+		return r.NormalizeText(text)
+	}
+
+	words := r.splitWords(text)
+	r.stemParallel(words, workers)
+
+	return strings.Join(words, r.separator)
+}
+
+// StemAll returns the bases of the words in the same order, stemming them with one worker per CPU,
+// as reported by runtime.GOMAXPROCS. Every worker uses its own clone of r.
+func (r *RuStemmer) StemAll(words []string) []string {
+	stems := append([]string{}, words...)
+	r.stemParallel(stems, runtime.GOMAXPROCS(0))
+
+	return stems
+}
+
+// stemParallel replaces the words with their bases in place, splitting them between the workers.
+func (r *RuStemmer) stemParallel(words []string, workers int) {
+	if workers <= 1 {
+		for k, word := range words {
+			words[k] = r.GetWordBase(word)
+		}
+		return
+	}
+
+	chunkSize := (len(words) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(words); start += chunkSize {
+		end := start + chunkSize
+		if end > len(words) {
+			end = len(words)
+		}
+
+		wg.Add(1)
+		go func(chunk []string) {
+			defer wg.Done()
+			stemmer := r.Clone()
+			for k, word := range chunk {
+				chunk[k] = stemmer.GetWordBase(word)
+			}
+		}(words[start:end])
+	}
+	wg.Wait()
+}
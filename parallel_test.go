@@ -0,0 +1,63 @@
+package rustemmer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTextParallel(t *testing.T) {
+	text := strings.Repeat(
+		"Результаты проверки города в DB: \"Санкт-Петербурга\" не нашлось! "+
+			"Важная новость (!) В вагоне метро заклинило вал. ",
+		1000,
+	)
+	expected := NormalizeText(text)
+
+	for _, workers := range []int{-1, 0, 1, 2, 3, 8, 64} {
+		if result := NormalizeTextParallel(text, workers); result != expected {
+			t.Errorf("Output with %d workers differs from NormalizeText", workers)
+		}
+	}
+
+	stemmer := New(WithCache(10), WithOutputSeparator("|"))
+	if result := stemmer.NormalizeTextParallel(text, 4); result != stemmer.NormalizeText(text) {
+		t.Errorf("Output with a configured stemmer differs from NormalizeText")
+	}
+
+	if result := NormalizeTextParallel("", 4); result != "" {
+		t.Errorf("Expected empty text, got %q", result)
+	}
+	if result := NormalizeTextParallel("вазы", 4); result != "ваз" {
+		t.Errorf("Not equal: ваз != %s", result)
+	}
+}
+
+func TestStemAll(t *testing.T) {
+	words := strings.Fields(strings.Repeat("вагоны важнейшими Windows в ценнейший ", 1000))
+	stems := StemAll(words)
+	if len(stems) != len(words) {
+		t.Fatalf("Not equal: %d != %d", len(words), len(stems))
+	}
+	for k, word := range words {
+		if base := GetWordBase(word); stems[k] != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, stems[k])
+		}
+	}
+	if words[0] != "вагоны" {
+		t.Errorf("The input was modified: %s", words[0])
+	}
+
+	if stems := StemAll(nil); len(stems) != 0 {
+		t.Errorf("Expected no stems, got %v", stems)
+	}
+}
+
+func TestClone(t *testing.T) {
+	stemmer := New(WithCaseFolding(), WithOutputSeparator("|"))
+	stemmer.AddNounSuffixes("ация")
+
+	clone := stemmer.Clone()
+	if text := clone.NormalizeText("Важная организация"); text != "важн|организ" {
+		t.Errorf("Not equal: важн|организ != %s", text)
+	}
+}
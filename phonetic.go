@@ -0,0 +1,68 @@
+package rustemmer
+
+import (
+	"strings"
+)
+
+// phoneticFolds maps the letters that sound alike to one of them: unstressed "о" is pronounced as "а"
+// and "е" as "и", and voiced consonants are confused with their voiceless pairs.
+var phoneticFolds = map[rune]rune{
+	'о': 'а', 'ы': 'а', 'я': 'а',
+	'е': 'и', 'ё': 'и', 'э': 'и',
+	'ю': 'у',
+	'б': 'п', 'в': 'ф', 'г': 'к', 'д': 'т', 'ж': 'ш', 'з': 'с',
+}
+
+// PhoneticKey returns the phonetic key of the base of the word.
+func PhoneticKey(word string) string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.PhoneticKey(word)
+}
+
+// PhoneticKey returns the phonetic key of the base of the word, a simplified spelling that is the same
+// for words sounding alike, so misspelled words such as "малоко" and "молоко" get the same key.
+// As in the Russian adaptation of Metaphone, the base is converted to lower case, "о" and "а",
+// "е" and "и" and the voiced and voiceless pairs of consonants are folded into one letter,
+// "тс", "дс", "тц" and "дц" into "ц", the soft and hard signs are dropped and doubled letters are collapsed.
+// Other characters are kept in lower case.
+func (r *RuStemmer) PhoneticKey(word string) string {
+	return phoneticKey(r.GetWordBase(toLower(word)))
+}
+
+// phoneticKey returns the phonetic key of the base.
+func phoneticKey(base string) string {
+	var buf strings.Builder
+	buf.Grow(len(base))
+	runes := []rune(toLower(base))
+	var last rune
+	for k := 0; k < len(runes); k++ {
+		char := runes[k]
+		var next rune
+		if k + 1 < len(runes) {
+			next = runes[k + 1]
+		}
+
+		switch {
+		case char == 'ь' || char == 'ъ':
+			continue
+		case (char == 'й' || char == 'и') && (next == 'о' || next == 'е' || next == 'ё'):
+			char = 'и'
+			k++
+		case (char == 'т' || char == 'д') && (next == 'с' || next == 'ц'):
+			char = 'ц'
+			k++
+		default:
+			if folded, ok := phoneticFolds[char]; ok {
+				char = folded
+			}
+		}
+
+		if char != last {
+			buf.WriteRune(char)
+		}
+		last = char
+	}
+
+	return buf.String()
+}
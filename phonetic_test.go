@@ -0,0 +1,34 @@
+package rustemmer
+
+import (
+	"testing"
+)
+
+func TestPhoneticKey(t *testing.T) {
+	testWords := map[string]string{
+		"молоко"     : "малак",
+		"малоко"     : "малак",
+		"Молоко"     : "малак",
+		"вагоны"     : "факан",
+		"ваконы"     : "факан",
+		"здоровье"   : "стараф",
+		"сдоровье"   : "стараф",
+		"сердце"     : "сирц",
+		"серце"      : "сирц",
+		"детство"    : "тицтф",
+		"класс"      : "клас",
+		"подъезд"    : "патист",
+		"Windows"    : "windows",
+		""           : "",
+	}
+
+	for word, expected := range testWords {
+		if key := PhoneticKey(word); key != expected {
+			t.Errorf("Not equal: %s %s != %s", word, expected, key)
+		}
+	}
+
+	if PhoneticKey("вагон") == PhoneticKey("ваза") {
+		t.Errorf("Expected different keys for different words")
+	}
+}
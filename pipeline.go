@@ -0,0 +1,196 @@
+package rustemmer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// StageFunc is a stage of a Pipeline. It receives the tokens of a text, in which Stem holds
+// the current form of every word, and returns the transformed tokens, possibly filtering some out.
+// A stage may modify the slice it receives.
+type StageFunc func(tokens []Token) []Token
+
+// Pipeline splits texts into words and passes them through a sequence of stages.
+// A Pipeline is safe for concurrent use.
+type Pipeline struct {
+	stages    []StageFunc
+	separator string
+}
+
+// PipelineBuilder builds a Pipeline. The stages run in the order they are added.
+type PipelineBuilder struct {
+	stages    []StageFunc
+	separator string
+}
+
+// NewPipeline returns a builder of a Pipeline without stages, which only splits texts into words
+// and joins them with a space.
+func NewPipeline() *PipelineBuilder {
+	return &PipelineBuilder{separator: " "}
+}
+
+// WithSeparator sets the string Process puts between words instead of a single space.
+func (b *PipelineBuilder) WithSeparator(sep string) *PipelineBuilder {
+	b.separator = sep
+	return b
+}
+
+// WithCustomStage adds the stage to the pipeline.
+func (b *PipelineBuilder) WithCustomStage(fn StageFunc) *PipelineBuilder {
+	b.stages = append(b.stages, fn)
+	return b
+}
+
+// WithUnicodeNormalization adds a stage normalizing words to the Unicode NFC form,
+// which composes decomposed letters into their precomposed form.
+func (b *PipelineBuilder) WithUnicodeNormalization() *PipelineBuilder {
+	return b.WithCustomStage(mapStage(composeNFC))
+}
+
+// WithDiacriticStripping adds a stage removing diacritics, such as stress marks, from words,
+// as the WithDiacriticStripping option does.
+func (b *PipelineBuilder) WithDiacriticStripping() *PipelineBuilder {
+	return b.WithCustomStage(mapStage(stripDiacritics))
+}
+
+// WithCaseFolding adds a stage converting words to lower case.
+func (b *PipelineBuilder) WithCaseFolding() *PipelineBuilder {
+	return b.WithCustomStage(mapStage(toLower))
+}
+
+// WithYoNormalization adds a stage replacing the letter "ё" with "е".
+func (b *PipelineBuilder) WithYoNormalization() *PipelineBuilder {
+	return b.WithCustomStage(mapStage(yoReplacer.Replace))
+}
+
+// WithStopWords adds a stage dropping the words. Stop words are matched case-insensitively.
+func (b *PipelineBuilder) WithStopWords(words []string) *PipelineBuilder {
+	stopWords := stopWordSet(words)
+	return b.WithCustomStage(filterStage(func(word string) bool {
+		return !stopWords[strings.ToLower(word)]
+	}))
+}
+
+// WithRussianStopWords adds a stage dropping the built-in Russian stop words, as WithStopWords(RussianStopWords()) does.
+func (b *PipelineBuilder) WithRussianStopWords() *PipelineBuilder {
+	return b.WithCustomStage(filterStage(func(word string) bool {
+		return !russianStopWordSet[strings.ToLower(word)]
+	}))
+}
+
+// WithMinLength adds a stage dropping words shorter than n runes.
+func (b *PipelineBuilder) WithMinLength(n int) *PipelineBuilder {
+	return b.WithCustomStage(filterStage(func(word string) bool {
+		return utf8.RuneCountInString(word) >= n
+	}))
+}
+
+// WithStemming adds a stage replacing words with their bases found by a stemmer created with the options.
+func (b *PipelineBuilder) WithStemming(opts ...Option) *PipelineBuilder {
+	pool := NewStemmerPool(opts...)
+
+	return b.WithCustomStage(func(tokens []Token) []Token {
+		r := pool.Get()
+		defer pool.Put(r)
+		for k := range tokens {
+			tokens[k].Stem = r.GetWordBase(tokens[k].Stem)
+		}
+
+		return tokens
+	})
+}
+
+// Lower is a short form of WithCaseFolding.
+func (b *PipelineBuilder) Lower() *PipelineBuilder {
+	return b.WithCaseFolding()
+}
+
+// FoldYo is a short form of WithYoNormalization.
+func (b *PipelineBuilder) FoldYo() *PipelineBuilder {
+	return b.WithYoNormalization()
+}
+
+// RemoveStopwords is a short form of WithRussianStopWords.
+func (b *PipelineBuilder) RemoveStopwords() *PipelineBuilder {
+	return b.WithRussianStopWords()
+}
+
+// Stem is a short form of WithStemming.
+func (b *PipelineBuilder) Stem(opts ...Option) *PipelineBuilder {
+	return b.WithStemming(opts...)
+}
+
+// Join is a short form of WithSeparator.
+func (b *PipelineBuilder) Join(sep string) *PipelineBuilder {
+	return b.WithSeparator(sep)
+}
+
+// Build returns the pipeline. The builder may be reused to build other pipelines.
+func (b *PipelineBuilder) Build() *Pipeline {
+	return &Pipeline{stages: append([]StageFunc{}, b.stages...), separator: b.separator}
+}
+
+// Process returns the words of the text that pass all the stages, transformed by them
+// and separated by a space or the separator set with WithSeparator.
+func (p *Pipeline) Process(text string) string {
+	tokens := p.ProcessTokens(text)
+	words := make([]string, len(tokens))
+	for k, token := range tokens {
+		words[k] = token.Stem
+	}
+
+	return strings.Join(words, p.separator)
+}
+
+// ProcessSentences splits the text into sentences, as Sentences does, and returns the result of Process for each of them.
+func (p *Pipeline) ProcessSentences(text string) []string {
+	sentences := Sentences(text)
+	for k, sentence := range sentences {
+		sentences[k] = p.Process(sentence)
+	}
+
+	return sentences
+}
+
+// ProcessTokens returns the words of the text that pass all the stages, with the forms produced
+// by the stages in Stem. Original, Start and End describe the words as they appear in the text.
+func (p *Pipeline) ProcessTokens(text string) []Token {
+	indexes := wordTokenizer.WordIndexes(text)
+	tokens := make([]Token, len(indexes))
+	for k, loc := range indexes {
+		word := text[loc[0]:loc[1]]
+		tokens[k] = Token{Original: word, Stem: word, Start: loc[0], End: loc[1]}
+	}
+	setRuneOffsets(text, tokens)
+
+	for _, stage := range p.stages {
+		tokens = stage(tokens)
+	}
+
+	return tokens
+}
+
+// mapStage returns a stage replacing every word with fn(word).
+func mapStage(fn func(word string) string) StageFunc {
+	return func(tokens []Token) []Token {
+		for k := range tokens {
+			tokens[k].Stem = fn(tokens[k].Stem)
+		}
+
+		return tokens
+	}
+}
+
+// filterStage returns a stage keeping only the words for which keep returns true.
+func filterStage(keep func(word string) bool) StageFunc {
+	return func(tokens []Token) []Token {
+		ret := tokens[:0]
+		for _, token := range tokens {
+			if keep(token.Stem) {
+				ret = append(ret, token)
+			}
+		}
+
+		return ret
+	}
+}
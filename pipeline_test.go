@@ -0,0 +1,76 @@
+package rustemmer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	text := "Ёжик и Медведь смотрели на звёзды в тумане"
+	testPipelines := []struct {
+		builder  *PipelineBuilder
+		expected string
+	}{
+		{NewPipeline(), "Ёжик и Медведь смотрели на звёзды в тумане"},
+		{NewPipeline().WithStemming(), "Ёжик и Медвед смотрел на звёзд в туман"},
+		{
+			NewPipeline().WithCaseFolding().WithYoNormalization().WithStopWords([]string{"И", "на"}).
+				WithMinLength(2).WithStemming(),
+			"ежик медвед смотрел звезд туман",
+		},
+		// The stages run in order, so words are filtered by their length after stemming.
+		{NewPipeline().WithStemming().WithMinLength(6), "Медвед смотрел"},
+		{
+			NewPipeline().WithCaseFolding().WithRussianStopWords().WithStemming().WithSeparator(","),
+			"ёжик,медвед,смотрел,звёзд,туман",
+		},
+		{NewPipeline().Lower().FoldYo().RemoveStopwords().Stem().Join(" "), "ежик медвед смотрел звезд туман"},
+	}
+
+	for _, test := range testPipelines {
+		if processed := test.builder.Build().Process(text); processed != test.expected {
+			t.Errorf("Not equal: %s != %s", test.expected, processed)
+		}
+	}
+}
+
+func TestPipelineProcessTokens(t *testing.T) {
+	pipeline := NewPipeline().WithCaseFolding().WithStopWords([]string{"в"}).WithStemming().Build()
+	expected := []Token{
+		{Original: "Вагоны", Stem: "вагон", Start: 0, End: 12, RuneStart: 0, RuneEnd: 6},
+		{Original: "депо", Stem: "деп", Start: 16, End: 24, RuneStart: 9, RuneEnd: 13},
+	}
+
+	if tokens := pipeline.ProcessTokens("Вагоны в депо"); !reflect.DeepEqual(expected, tokens) {
+		t.Errorf("Not equal: %v != %v", expected, tokens)
+	}
+	if tokens := pipeline.ProcessTokens(""); len(tokens) != 0 {
+		t.Errorf("Expected no tokens, got %v", tokens)
+	}
+}
+
+func TestPipelineCustomStage(t *testing.T) {
+	builder := NewPipeline().WithStemming().WithCustomStage(func(tokens []Token) []Token {
+		for k := range tokens {
+			tokens[k].Stem = strings.ToUpper(tokens[k].Stem)
+		}
+		return tokens
+	})
+	pipeline := builder.Build()
+	builder.WithMinLength(10)
+
+	if processed := pipeline.Process("важные вагоны"); processed != "ВАЖН ВАГОН" {
+		t.Errorf("Not equal: %s != %s", "ВАЖН ВАГОН", processed)
+	}
+}
+
+func TestPipelineProcessSentences(t *testing.T) {
+	pipeline := NewPipeline().WithCaseFolding().WithStemming().Build()
+
+	sentences := pipeline.ProcessSentences("Офис: г. Москва, ул. Полярная. Вагоны стоят в депо!")
+	expected := []string{"офис г москв ул полярн", "вагон сто в деп"}
+	if !reflect.DeepEqual(sentences, expected) {
+		t.Errorf("Not equal: %v != %v", expected, sentences)
+	}
+}
@@ -0,0 +1,70 @@
+package rustemmer
+
+import (
+	"sync"
+)
+
+// GetPooled returns a stemmer taken from Pool together with a function returning it to the pool.
+// The stemmer must not be used after release is called, and must not be shared between goroutines before that:
+//
+//	r, release := rustemmer.GetPooled()
+//	defer release()
+//	for _, word := range words {
+//		bases = append(bases, r.GetWordBase(word))
+//	}
+func GetPooled() (r *RuStemmer, release func()) {
+	r = getPooled()
+	return r, func() {
+		Pool.Put(r)
+	}
+}
+
+var _ Stemmer = (*StemmerPool)(nil)
+var _ WordStemmer = (*StemmerPool)(nil)
+
+// StemmerPool is a pool of stemmers configured with the same options, like Pool is for the package-level functions.
+// Unlike a RuStemmer, a StemmerPool is safe for concurrent use, as every call takes a stemmer of its own from the pool.
+type StemmerPool struct {
+	pool sync.Pool
+}
+
+// NewStemmerPool creates a pool of stemmers configured with the given options.
+// The stemmers are clones of one stemmer, so they share its cache, if any.
+func NewStemmerPool(opts ...Option) *StemmerPool {
+	proto := New(opts...)
+	p := &StemmerPool{}
+	p.pool.New = func() interface{} {
+		return proto.Clone()
+	}
+
+	return p
+}
+
+// Get returns a stemmer of the pool, which the caller uses exclusively until it returns the stemmer with Put.
+func (p *StemmerPool) Get() *RuStemmer {
+	return p.pool.Get().(*RuStemmer)
+}
+
+// Put returns a stemmer taken with Get to the pool.
+func (p *StemmerPool) Put(r *RuStemmer) {
+	p.pool.Put(r)
+}
+
+// GetWordBase returns the base word.
+func (p *StemmerPool) GetWordBase(word string) string {
+	r := p.Get()
+	defer p.Put(r)
+	return r.GetWordBase(word)
+}
+
+// Stem returns the base word. It is the same as GetWordBase and implements WordStemmer.
+func (p *StemmerPool) Stem(word string) string {
+	return p.GetWordBase(word)
+}
+
+// NormalizeText returns text in which all words are replaced with their bases, as RuStemmer.NormalizeText does.
+func (p *StemmerPool) NormalizeText(text string) string {
+	r := p.Get()
+	defer p.Put(r)
+	return r.NormalizeText(text)
+}
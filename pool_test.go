@@ -0,0 +1,50 @@
+package rustemmer
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestStemmerPool(t *testing.T) {
+	pool := NewStemmerPool(WithCaseFolding(), WithCache(16))
+	testWords := map[string]string{
+		"Вазы"     : "ваз",
+		"вагонов"  : "вагон",
+		"ВАЖНОСТИ" : "важност",
+		"валялся"  : "валя",
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				for word, base := range testWords {
+					if testBase := pool.GetWordBase(word); testBase != base {
+						t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+					}
+				}
+				if text := pool.NormalizeText("Вазы в вагонах"); text != "ваз в вагон" {
+					t.Errorf("Not equal: ваз в вагон != %s", text)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	r := pool.Get()
+	defer pool.Put(r)
+	if stats := r.CacheStats(); stats.Hits == 0 {
+		t.Errorf("Expected the stemmers of the pool to share the cache, got %+v", stats)
+	}
+}
+
+func TestGetPooled(t *testing.T) {
+	r, release := GetPooled()
+	defer release()
+
+	if base := r.GetWordBase("вазы"); base != "ваз" {
+		t.Errorf("Not equal: ваз != %s", base)
+	}
+}
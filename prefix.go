@@ -0,0 +1,50 @@
+package rustemmer
+
+import (
+	"unicode/utf8"
+)
+
+// MinPrefixStrippedLength is the minimal length, in runes, of a base left after prefix stripping.
+const MinPrefixStrippedLength = 3
+
+// WithPrefixStripping enables removal of the given prefixes, such as "по", "про", "пере", "за", "под" and "раз",
+// from the base after all the Porter steps, so that "переписать" and "писать" share a base.
+// The longest matching prefix is removed, and only if at least MinPrefixStrippedLength runes remain.
+// This is not part of the Porter algorithm: it is lossy and meant to improve recall in search.
+func WithPrefixStripping(prefixes []string) Option {
+	return func(r *RuStemmer) {
+		r.prefixes = mergeSuffixes(nil, prefixes)
+	}
+}
+
+// stripPrefix removes the longest configured prefix from the word and returns it.
+func (r *RuStemmer) stripPrefix() string {
+	for _, prefix := range r.prefixes {
+		n := prefixLength(r.word, prefix)
+		if n == 0 || len(r.word) - n < MinPrefixStrippedLength {
+			continue
+		}
+
+		r.word = append(r.word[:0], r.word[n:]...)
+		r.recordStep(StepPrefix)
+		r.traceStep(StepPrefix, prefix)
+		return prefix
+	}
+
+	return ""
+}
+
+// prefixLength returns the length of the prefix in runes if the word starts with it, or 0 otherwise.
+func prefixLength(word []rune, prefix string) int {
+	i := 0
+	for len(prefix) > 0 {
+		char, size := utf8.DecodeRuneInString(prefix)
+		if i >= len(word) || word[i] != char {
+			return 0
+		}
+		i++
+		prefix = prefix[size:]
+	}
+
+	return i
+}
@@ -0,0 +1,42 @@
+package rustemmer
+
+import (
+	"testing"
+	"reflect"
+)
+
+func TestWithPrefixStripping(t *testing.T) {
+	stemmer := New(WithPrefixStripping([]string{"по", "про", "пере", "за", "под", "раз"}))
+
+	testWords := map[string]string{
+		"переписать" : "писа",
+		"писать"     : "писа",
+		"подписать"  : "писа",
+		"прочитать"  : "чита",
+		"пол"        : "пол",
+		"позы"       : "поз",
+		"завод"      : "вод",
+	}
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+
+	if base := GetWordBase("переписать"); base != "переписа" {
+		t.Errorf("Not equal: переписа != %s", base)
+	}
+}
+
+func TestWithPrefixStrippingTrace(t *testing.T) {
+	stemmer := New(WithPrefixStripping([]string{"пере"}))
+
+	_, trace := stemmer.GetWordBaseTrace("переписать")
+	expected := []StepTrace{
+		{Step: StepVerb, Suffix: "ть", Word: "переписа"},
+		{Step: StepPrefix, Suffix: "пере", Word: "писа"},
+	}
+	if !reflect.DeepEqual(expected, trace) {
+		t.Errorf("Not equal: %v != %v", expected, trace)
+	}
+}
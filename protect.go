@@ -0,0 +1,83 @@
+package rustemmer
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxAbbreviationLength is the maximal length, in runes, of a word detected as an abbreviation.
+// Longer words written in capitals are more likely emphasized or headline words.
+const maxAbbreviationLength = 5
+
+// WithProtectedWords makes the stemmer return the words, such as proper nouns, unchanged.
+// The words are matched case-insensitively, and are left out of any normalization.
+func WithProtectedWords(words []string) Option {
+	return func(r *RuStemmer) {
+		r.AddProtectedWords(words)
+	}
+}
+
+// WithAbbreviationDetection makes the stemmer return abbreviations, such as "МГУ", "РФ" and "ООО", unchanged,
+// even if words are converted to lower case. Abbreviations are words of two to five letters, all of them capitals.
+func WithAbbreviationDetection() Option {
+	return func(r *RuStemmer) {
+		r.abbreviationDetection = true
+	}
+}
+
+// AddProtectedWords adds words that the stemmer returns unchanged, as WithProtectedWords does.
+// The protected words are copied when they are changed, so AddProtectedWords does not affect the clones
+// of the stemmer, which may keep stemming concurrently.
+func (r *RuStemmer) AddProtectedWords(words []string) {
+	if len(words) == 0 {
+		return
+	}
+
+	protected := make(map[string]bool, len(r.protectedWords) + len(words))
+	for word := range r.protectedWords {
+		protected[word] = true
+	}
+	for _, word := range words {
+		protected[toLower(word)] = true
+	}
+	r.protectedWords = protected
+}
+
+// LoadProtectedWords adds the protected words read from src, one per line, as AddProtectedWords does.
+// Empty lines and lines starting with "#" are ignored.
+func (r *RuStemmer) LoadProtectedWords(src io.Reader) error {
+	var words []string
+	scanner := bufio.NewScanner(src)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	r.AddProtectedWords(words)
+	return nil
+}
+
+// isProtected reports whether the word is a protected word or a detected abbreviation.
+func (r *RuStemmer) isProtected(word string) bool {
+	return len(r.protectedWords) > 0 && r.protectedWords[toLower(word)] ||
+		r.abbreviationDetection && isAbbreviation(word)
+}
+
+// isAbbreviation reports whether the word consists of two to five capital letters.
+func isAbbreviation(word string) bool {
+	if n := utf8.RuneCountInString(word); n < 2 || n > maxAbbreviationLength {
+		return false
+	}
+
+	return strings.IndexFunc(word, func(char rune) bool {
+		return !unicode.IsUpper(char)
+	}) < 0
+}
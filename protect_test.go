@@ -0,0 +1,70 @@
+package rustemmer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithProtectedWords(t *testing.T) {
+	stemmer := New(WithCaseFolding(), WithProtectedWords([]string{"Москва", "ПУТИНА"}))
+	testWords := map[string]string{
+		"Москва" : "Москва",
+		"москва" : "москва",
+		"Москвы" : "москв",
+		"путина" : "путина",
+		"вагоны" : "вагон",
+	}
+
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+
+	if text := stemmer.NormalizeText("Москва, вагоны Москвы"); text != "Москва вагон москв" {
+		t.Errorf("Not equal: Москва вагон москв != %s", text)
+	}
+}
+
+func TestLoadProtectedWords(t *testing.T) {
+	stemmer := New(WithCache(16))
+	if base := stemmer.GetWordBase("Киеве"); base != "Киев" {
+		t.Errorf("Not equal: Киев != %s", base)
+	}
+
+	if err := stemmer.LoadProtectedWords(strings.NewReader("# cities\nКиеве\n\n  Казани  \n")); err != nil {
+		t.Fatal(err)
+	}
+	for _, word := range []string{"Киеве", "Казани"} {
+		if base := stemmer.GetWordBase(word); base != word {
+			t.Errorf("Not equal: %s != %s", word, base)
+		}
+	}
+}
+
+func TestWithAbbreviationDetection(t *testing.T) {
+	stemmer := New(WithCaseFolding(), WithAbbreviationDetection())
+	testWords := map[string]string{
+		"МГУ"    : "МГУ",
+		"РФ"     : "РФ",
+		"ООО"    : "ООО",
+		"НАТО"   : "НАТО",
+		"ВАГОНЫ" : "вагон",
+		"Ой"     : "ой",
+		"Я"      : "я",
+		"ООО2"   : "ооо2",
+	}
+
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+
+	if base := New(WithCaseFolding()).GetWordBase("ООО"); base != "оо" {
+		t.Errorf("Not equal: оо != %s", base)
+	}
+	if text := stemmer.NormalizeText("Студенты МГУ и ООО «Вагоны»"); text != "студент МГУ и ООО вагон" {
+		t.Errorf("Not equal: студент МГУ и ООО вагон != %s", text)
+	}
+}
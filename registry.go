@@ -0,0 +1,85 @@
+package rustemmer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownLanguage is returned by NormalizeTextLang for languages without a registered stemmer.
+var ErrUnknownLanguage = errors.New("rustemmer: unknown language")
+
+// WordStemmer is the interface implemented by anything that stems single words,
+// so that the text functions of this package can be used with other languages.
+type WordStemmer interface {
+	// Stem returns the base of the word.
+	Stem(word string) string
+}
+
+var _ WordStemmer = (*RuStemmer)(nil)
+var _ WordStemmer = NoopStemmer{}
+
+// NoopStemmer is a WordStemmer that returns words unchanged.
+type NoopStemmer struct{}
+
+// Stem returns the word unchanged.
+func (NoopStemmer) Stem(word string) string {
+	return word
+}
+
+// pooledStemmer is a WordStemmer that stems Russian words with the stemmers of Pool,
+// so it is safe for concurrent use.
+type pooledStemmer struct{}
+
+// Stem returns the base of the word.
+func (pooledStemmer) Stem(word string) string {
+	return GetWordBase(word)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]WordStemmer{
+		"ru" : pooledStemmer{},
+	}
+)
+
+// Register makes the stemmer available for the language by ForLanguage, replacing the one registered before.
+// The language is an arbitrary key, such as an ISO 639-1 code; "ru" is registered by default.
+// As registered stemmers may be shared by goroutines, they should be safe for concurrent use.
+func Register(lang string, s WordStemmer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[lang] = s
+}
+
+// ForLanguage returns the stemmer registered for the language.
+func ForLanguage(lang string) (WordStemmer, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	s, ok := registry[lang]
+	return s, ok
+}
+
+// NormalizeTextLang returns text in which all words are replaced with their bases found by the stemmer
+// registered for the language, or ErrUnknownLanguage if there is none.
+func NormalizeTextLang(text, lang string) (string, error) {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.NormalizeTextLang(text, lang)
+}
+
+// NormalizeTextLang returns text in which all words are replaced with their bases found by the stemmer
+// registered for the language, as NormalizeTextWith does, or ErrUnknownLanguage if there is none.
+// Unless another stemmer was registered for "ru", Russian text is stemmed by r itself.
+func (r *RuStemmer) NormalizeTextLang(text, lang string) (string, error) {
+	s, ok := ForLanguage(lang)
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownLanguage, lang)
+	}
+	// The default Russian stemmer is replaced with r, so its options apply.
+	if _, ok := s.(pooledStemmer); ok {
+		s = r
+	}
+
+	return r.NormalizeTextWith(s, text), nil
+}
@@ -0,0 +1,112 @@
+package rustemmer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// upperStemmer is a WordStemmer for tests that upper-cases words.
+type upperStemmer struct{}
+
+func (upperStemmer) Stem(word string) string {
+	return strings.ToUpper(word)
+}
+
+func TestNormalizeTextWith(t *testing.T) {
+	text := "Важная новость: в вагоне метро заклинило вал!"
+	testStemmers := []struct {
+		stemmer  WordStemmer
+		expected string
+	}{
+		{New(), "Важн новост в вагон метр заклин вал"},
+		{NoopStemmer{}, "Важная новость в вагоне метро заклинило вал"},
+	}
+
+	for _, test := range testStemmers {
+		if normalized := NormalizeTextWith(test.stemmer, text); normalized != test.expected {
+			t.Errorf("Not equal: %s != %s", test.expected, normalized)
+		}
+	}
+
+	// Tokenizing, stop words and joining are configured on the stemmer running the pipeline.
+	pipeline := New(WithStopWords([]string{"в", "метро"}), WithOutputSeparator("|"))
+	testStemmers = []struct {
+		stemmer  WordStemmer
+		expected string
+	}{
+		{pipeline, "Важн|новост|вагон|заклин|вал"},
+		{NoopStemmer{}, "Важная|новость|вагоне|заклинило|вал"},
+		{upperStemmer{}, "ВАЖНАЯ|НОВОСТЬ|ВАГОНЕ|ЗАКЛИНИЛО|ВАЛ"},
+	}
+
+	for _, test := range testStemmers {
+		if normalized := pipeline.NormalizeTextWith(test.stemmer, text); normalized != test.expected {
+			t.Errorf("Not equal: %s != %s", test.expected, normalized)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	ru, ok := ForLanguage("ru")
+	if !ok {
+		t.Fatal("Expected a stemmer for ru")
+	}
+	if base := ru.Stem("вагоны"); base != "вагон" {
+		t.Errorf("Not equal: %s != %s", "вагон", base)
+	}
+
+	if _, ok := ForLanguage("uk"); ok {
+		t.Error("Expected no stemmer for uk")
+	}
+	Register("uk", NoopStemmer{})
+	defer func() {
+		registryMu.Lock()
+		delete(registry, "uk")
+		registryMu.Unlock()
+	}()
+
+	uk, ok := ForLanguage("uk")
+	if !ok {
+		t.Fatal("Expected a stemmer for uk")
+	}
+	if base := uk.Stem("вагони"); base != "вагони" {
+		t.Errorf("Not equal: %s != %s", "вагони", base)
+	}
+	if normalized := NormalizeTextWith(uk, "Нові вагони!"); normalized != "Нові вагони" {
+		t.Errorf("Not equal: %s != %s", "Нові вагони", normalized)
+	}
+}
+
+func TestNormalizeTextLang(t *testing.T) {
+	Register("xx", upperStemmer{})
+	defer func() {
+		registryMu.Lock()
+		delete(registry, "xx")
+		registryMu.Unlock()
+	}()
+
+	testLangs := map[string]string{
+		"ru" : "Нов вагон",
+		"xx" : "НОВЫЕ ВАГОНЫ",
+	}
+	for lang, expected := range testLangs {
+		normalized, err := NormalizeTextLang("Новые вагоны!", lang)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if normalized != expected {
+			t.Errorf("Not equal: %s != %s", expected, normalized)
+		}
+	}
+
+	stemmer := New()
+	stemmer.AddException("вагоны", "вагоны")
+	if normalized, _ := stemmer.NormalizeTextLang("Новые вагоны!", "ru"); normalized != "Нов вагоны" {
+		t.Errorf("Not equal: Нов вагоны != %s", normalized)
+	}
+
+	if _, err := New().NormalizeTextLang("Новые вагоны!", "zz"); !errors.Is(err, ErrUnknownLanguage) {
+		t.Errorf("Expected ErrUnknownLanguage, got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package rustemmer
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+
+	"github.com/liderman/rustemmer/internal/porter"
+)
+
+// ErrInvalidSuffix is returned by Rules.Validate for a suffix the steps cannot match.
+var ErrInvalidSuffix = errors.New("rustemmer: invalid suffix")
+
+// participleMarkers are the groups of participle suffixes, such as "ющ", which precede the adjective endings
+// in the participle endings. The first group is removed only after "а" or "я".
+var participleMarkers = [2][]string{
+	{"ем", "нн", "вш", "ющ", "щ"},
+	{"ивш", "ывш", "ующ"},
+}
+
+// Rules holds the suffix tables of the Porter steps. Get the tables of the algorithm with DefaultRules,
+// change them and pass them to WithRules, for instance to remove domain-specific derivational suffixes
+// such as "чик" and "щик". The suffixes are matched in lower case and may be listed in any order;
+// the longest matching suffix is removed.
+// The tables in two groups hold in the first group the suffixes removed only after "а" or "я".
+type Rules struct {
+	// PerfectiveGerunds are the perfective gerund endings, such as "вшись".
+	PerfectiveGerunds [2][]string
+	// Reflexives are the reflexive endings, such as "ся".
+	Reflexives []string
+	// Adjectives are the adjective endings, such as "ого".
+	Adjectives []string
+	// Participles are the participle suffixes, such as "ющ". The participle endings removed by the steps
+	// are the adjective endings preceded by them.
+	Participles [2][]string
+	// Verbs are the verb endings, such as "ила".
+	Verbs [2][]string
+	// Nouns are the noun endings, such as "ями".
+	Nouns []string
+	// Superlatives are the superlative suffixes, such as "ейш".
+	Superlatives []string
+	// Derivationals are the derivational suffixes removed in R2, such as "ость".
+	Derivationals []string
+}
+
+// DefaultRules returns a copy of the suffix tables of the algorithm, which may be modified freely.
+func DefaultRules() Rules {
+	rules := Rules{
+		PerfectiveGerunds: [2][]string{suffixPerfectiveGerunds[0], suffixPerfectiveGerunds[1]},
+		Reflexives:        suffixReflexives,
+		Adjectives:        suffixAdjective,
+		Participles:       participleMarkers,
+		Verbs:             [2][]string{suffixVerb[0], suffixVerb[1]},
+		Nouns:             suffixNoun,
+		Superlatives:      suffixSuperlative,
+		Derivationals:     suffixDerivational,
+	}
+
+	return rules.Clone()
+}
+
+// Clone returns a copy of the rules that shares no tables with them.
+func (rules Rules) Clone() Rules {
+	for _, table := range rules.allTables() {
+		*table = append([]string(nil), *table...)
+	}
+
+	return rules
+}
+
+// Validate returns an error wrapping ErrInvalidSuffix if a suffix of the tables is empty
+// or has characters other than lower case letters.
+func (rules Rules) Validate() error {
+	for _, table := range rules.allTables() {
+		for _, suffix := range *table {
+			if suffix == "" {
+				return fmt.Errorf("%w %q", ErrInvalidSuffix, suffix)
+			}
+			for _, char := range suffix {
+				if !unicode.IsLower(char) {
+					return fmt.Errorf("%w %q", ErrInvalidSuffix, suffix)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// WithRules makes the stemmer remove the suffixes of the rules instead of the built-in ones.
+// The tables are validated and compiled when WithRules is called, so the stemmers created
+// with the option share them. It panics if the rules are not valid; call Validate first for rules
+// that come from user input.
+func WithRules(rules Rules) Option {
+	if err := rules.Validate(); err != nil {
+		panic(err)
+	}
+
+	adjective := mergeSuffixes(nil, rules.Adjectives)
+	gerunds := newSuffixTries([][]string{
+		mergeSuffixes(nil, rules.PerfectiveGerunds[0]),
+		mergeSuffixes(nil, rules.PerfectiveGerunds[1]),
+	})
+	reflexives := porter.NewTrie(mergeSuffixes(nil, rules.Reflexives))
+	adjectives := porter.NewTrie(adjective)
+	participles := newSuffixTries([][]string{
+		mergeSuffixes(nil, appendPrefix(adjective, rules.Participles[0])),
+		mergeSuffixes(nil, appendPrefix(adjective, rules.Participles[1])),
+	})
+	verbs := newSuffixTries([][]string{mergeSuffixes(nil, rules.Verbs[0]), mergeSuffixes(nil, rules.Verbs[1])})
+	nouns := porter.NewTrie(mergeSuffixes(nil, rules.Nouns))
+	superlatives := porter.NewTrie(mergeSuffixes(nil, rules.Superlatives))
+	derivationals := porter.NewTrie(mergeSuffixes(nil, rules.Derivationals))
+
+	return func(r *RuStemmer) {
+		r.suffixPerfectiveGerunds = gerunds
+		r.suffixReflexives = reflexives
+		r.suffixAdjective = adjectives
+		r.suffixParticiple = participles
+		r.suffixVerb = verbs
+		r.suffixNoun = nouns
+		r.suffixSuperlative = superlatives
+		r.suffixDerivational = derivationals
+		r.resetCache()
+	}
+}
+
+// allTables returns pointers to all tables of the rules.
+func (rules *Rules) allTables() []*[]string {
+	return []*[]string{
+		&rules.PerfectiveGerunds[0], &rules.PerfectiveGerunds[1], &rules.Reflexives, &rules.Adjectives,
+		&rules.Participles[0], &rules.Participles[1], &rules.Verbs[0], &rules.Verbs[1], &rules.Nouns,
+		&rules.Superlatives, &rules.Derivationals,
+	}
+}
@@ -0,0 +1,81 @@
+package rustemmer
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWithRulesDefault(t *testing.T) {
+	file, err := os.Open("testdata/stems.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	stemmer := New(WithRules(DefaultRules()))
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if base := stemmer.GetWordBase(fields[0]); base != fields[1] {
+			t.Errorf("Not equal: [%s] %s != %s", fields[0], fields[1], base)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithRules(t *testing.T) {
+	rules := DefaultRules()
+	rules.Derivationals = append(rules.Derivationals, "чик", "щик")
+	stemmer := New(WithRules(rules))
+
+	testWords := map[string]string{
+		"заказчик"     : "заказ",
+		"заказчикам"   : "заказ",
+		"сварщик"      : "сварщик",
+		"бдительность" : "бдительн",
+		"вагоны"       : "вагон",
+	}
+	for word, expected := range testWords {
+		if base := stemmer.GetWordBase(word); base != expected {
+			t.Errorf("Not equal: [%s] %s != %s", word, expected, base)
+		}
+	}
+
+	if base := GetWordBase("заказчик"); base != "заказчик" {
+		t.Errorf("Not equal: заказчик != %s", base)
+	}
+
+	// The rules are copied, so changing the default ones does not affect others.
+	rules = DefaultRules()
+	rules.Nouns[0] = "xyz"
+	if DefaultRules().Nouns[0] == "xyz" {
+		t.Error("Expected DefaultRules to return a copy")
+	}
+}
+
+func TestRulesValidate(t *testing.T) {
+	if err := DefaultRules().Validate(); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	for _, suffix := range []string{"", "Ам", "а-м", "ам "} {
+		rules := DefaultRules()
+		rules.Verbs[1] = append(rules.Verbs[1], suffix)
+		if err := rules.Validate(); !errors.Is(err, ErrInvalidSuffix) {
+			t.Errorf("Expected ErrInvalidSuffix for %q, got %v", suffix, err)
+		}
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrInvalidSuffix) {
+			t.Errorf("Expected a panic with ErrInvalidSuffix, got %v", err)
+		}
+	}()
+	WithRules(Rules{Nouns: []string{""}})
+}
@@ -2,162 +2,916 @@
 package rustemmer
 
 import (
-	"regexp"
+	"bytes"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/liderman/rustemmer/internal/porter"
 )
 
-var instance = New()
+// Pool is the pool of stemmers used by the package-level functions.
+// Stemmers are created lazily on demand as clones of the stemmer set with SetDefault or Configure.
+// A stemmer taken with Pool.Get is not safe for concurrent use and should be returned with Pool.Put
+// when it is no longer needed. The package-level functions replace the stemmers configured before
+// the last call of SetDefault; those taken with Pool.Get directly keep their configuration.
+var Pool = sync.Pool{
+	New: func() interface{} {
+		return cloneDefault(defaultStemmer.Load().(*RuStemmer))
+	},
+}
+
+// defaultStemmer holds the stemmer the stemmers of Pool are cloned from.
+var defaultStemmer atomic.Value
+
+func init() {
+	defaultStemmer.Store(New())
+}
+
+// SetDefault makes the package-level functions use clones of the stemmer. The stemmer is cloned with its own
+// exceptions, protected words and stop words, so changing it afterwards does not affect them; a nil stemmer
+// restores the default behavior. SetDefault is safe to call concurrently with the package-level functions:
+// calls already running finish with the previous configuration, and later ones use the new one.
+func SetDefault(r *RuStemmer) {
+	if r == nil {
+		r = New()
+	}
+	defaultStemmer.Store(r.deepClone())
+}
+
+// Configure sets the options of the stemmers used by the package-level functions, as SetDefault(New(opts...)) does.
+// Calling Configure without options restores the default behavior.
+func Configure(opts ...Option) {
+	SetDefault(New(opts...))
+}
+
+// getPooled takes a stemmer from Pool, replacing it with a new one if it was configured
+// before the last call of SetDefault.
+func getPooled() *RuStemmer {
+	r := Pool.Get().(*RuStemmer)
+	if proto := defaultStemmer.Load().(*RuStemmer); r.defaults != proto {
+		r = cloneDefault(proto)
+	}
+
+	return r
+}
+
+// cloneDefault returns a clone of the default stemmer for Pool.
+func cloneDefault(proto *RuStemmer) *RuStemmer {
+	r := proto.Clone()
+	r.defaults = proto
 
+	return r
+}
+
+// with returns the stemmer itself if there are no options, or otherwise a clone of it configured with them,
+// for the per-call options of the package-level functions. The clone has no cache, unless the options enable one.
+// Exceptions and protected words are copied when they are added, so the options leave the stemmer as it is.
+func (r *RuStemmer) with(opts []Option) *RuStemmer {
+	if len(opts) == 0 {
+		return r
+	}
+
+	c := r.Clone()
+	c.cache = nil
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// deepClone returns a clone of the stemmer with its own copies of the exceptions, the protected words and the stop words.
+func (r *RuStemmer) deepClone() *RuStemmer {
+	c := r.Clone()
+	if r.exceptions != nil {
+		c.exceptions = make(map[string]string, len(r.exceptions))
+		for word, stem := range r.exceptions {
+			c.exceptions[word] = stem
+		}
+	}
+	c.protectedWords = copySet(r.protectedWords)
+	c.stopWords = copySet(r.stopWords)
+
+	return c
+}
+
+// copySet returns a copy of the set of words, or nil if it is nil.
+func copySet(words map[string]bool) map[string]bool {
+	if words == nil {
+		return nil
+	}
+
+	c := make(map[string]bool, len(words))
+	for word := range words {
+		c[word] = true
+	}
+
+	return c
+}
+
+// VOWEL lists the Russian vowels used to find the regions of a word.
+// As in Snowball, "й" is a consonant, so "армия" and "армий" share the regions RV and R2.
 const VOWEL = "аеёиоуыэюя"
 
-var suffixNN = []string{"нн"}
+// The suffix tables are ordered from the longest to the shortest suffix by mergeSuffixes.
+// They are matched through the tries built from them, which find the longest matching suffix,
+// as the Porter algorithm requires.
+var suffixNN = porter.NewTrie([]string{"нн"})
 var suffixPerfectiveGerunds = [][]string{
-	{"в", "вши", "вшись", "в", "вши", "вшись"},
-	{"ив", "ивши", "ившись", "ыв", "ывши", "ывшись"},
+	mergeSuffixes(nil, []string{"в", "вши", "вшись"}),
+	mergeSuffixes(nil, []string{"ив", "ивши", "ившись", "ыв", "ывши", "ывшись"}),
 }
 var suffixReflexives = []string{"ся", "сь"}
-var suffixAdjective = []string{
+var suffixAdjective = mergeSuffixes(nil, []string{
 	"ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом", "его", "ого", "ему",
 	"ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею",
-}
+})
 var suffixVerb = [][]string{
-	{"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно"},
-	{
+	mergeSuffixes(nil, []string{
+		"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно",
+	}),
+	mergeSuffixes(nil, []string{
 		"ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым", "ен",
 		"ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю",
-	},
+	}),
 }
 
-var suffixNoun = []string{
+var suffixNoun = mergeSuffixes(nil, []string{
 	"а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой", "ий", "й", "иям",
 	"ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю", "ия", "ья", "я",
+})
+var suffixSuperlative = mergeSuffixes(nil, []string{"ейш", "ейше"})
+var suffixSoftSign = porter.NewTrie([]string{"ь"})
+var suffixI = porter.NewTrie([]string{"и"})
+var suffixDerivational = mergeSuffixes(nil, []string{"ост", "ость"})
+var suffixParticiple = participleSuffixes(suffixAdjective)
+
+// Stemmer is the interface implemented by stemmers of this package.
+type Stemmer interface {
+	// GetWordBase returns the base word.
+	GetWordBase(word string) string
+	// NormalizeText returns text in which all words are replaced with their bases.
+	NormalizeText(text string) string
 }
-var suffixSuperlative = []string{"ейш", "ейше"}
-var suffixSoftSign = []string{"ь"}
-var suffixI = []string{"и"}
-var suffixDerivational = []string{"ост", "ость"}
-var suffixParticiple = [][]string{
-	appendPrefix(suffixAdjective, []string{"ем", "нн", "вш", "ющ", "щ"}),
-	appendPrefix(suffixAdjective, []string{"ивш", "ывш", "ующ"}),
-}
 
+var _ Stemmer = (*RuStemmer)(nil)
+
+// RuStemmer is a Stemmer for Russian language.
+// A RuStemmer is not safe for concurrent use; use the package-level functions, Pool or a StemmerPool instead,
+// or a Clone of the stemmer in every goroutine.
 type RuStemmer struct {
-	mu sync.Mutex
 	word []rune
 	RV int
+	R1 int
 	R2 int
+
+	unicodeNormalization  bool
+	diacriticStripping    bool
+	historicalOrthography bool
+	yoNormalization       bool
+	caseHandling          CaseHandling
+	minWordLength         int
+	minStemLength         int
+	idempotentStems       bool
+	dropNonRussian        bool
+	numberPolicy          NumberPolicy
+	alphanumericPolicy    NumberPolicy
+	invalidWordPolicy     InvalidWordPolicy
+	invalidReplacement    string
+	nonRussianStemmer     WordStemmer
+	stopWords             map[string]bool
+	tokenizer             Tokenizer
+	compoundMode          CompoundMode
+	separator             string
+	outputTransform       func(base string) string
+	cache                 *stemCache
+	dictionary            *StemDict
+	lemmas                *LemmaDict
+	exceptions            map[string]string
+	protectedWords        map[string]bool
+	abbreviationDetection bool
+	trace                 *[]StepTrace
+	stats                 StatsObserver
+	steps                 []string
+	stepsVersion          int
+	defaults              *RuStemmer
+
+	suffixPerfectiveGerunds [2]*porter.Trie
+	suffixReflexives        *porter.Trie
+	suffixAdjective         *porter.Trie
+	suffixParticiple        [2]*porter.Trie
+	suffixVerb              [2]*porter.Trie
+	suffixNoun              *porter.Trie
+	suffixSuperlative       *porter.Trie
+	suffixDerivational      *porter.Trie
+	prefixes                []string
 }
 
-// New creates a new RuStemmer.
-func New() *RuStemmer {
-	return &RuStemmer{
+// New creates a new RuStemmer configured with the given options.
+func New(opts ...Option) *RuStemmer {
+	r := &RuStemmer{
 		word: []rune(""),
 		RV: 0,
+		R1: 0,
 		R2: 0,
+		tokenizer: wordTokenizer,
+		separator: " ",
+		unicodeNormalization: true,
+		minStemLength: DefaultMinStemLength,
+		suffixPerfectiveGerunds: triePerfectiveGerunds,
+		suffixReflexives: trieReflexives,
+		suffixAdjective: trieAdjective,
+		suffixParticiple: trieParticiple,
+		suffixVerb: trieVerb,
+		suffixNoun: trieNoun,
+		suffixSuperlative: trieSuperlative,
+		suffixDerivational: trieDerivational,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Clone returns a new stemmer with the same options and suffix tables as r.
+// The clone shares the cache of r, which is safe for concurrent use,
+// so the stemmers can be used from different goroutines.
+func (r *RuStemmer) Clone() *RuStemmer {
+	c := *r
+	c.word = []rune("")
+	c.RV = 0
+	c.R1 = 0
+	c.R2 = 0
+	c.steps = nil
+
+	return &c
 }
 
 // GetWordBase returns the base word.
 func GetWordBase(word string) string {
-	instance.mu.Lock()
-	defer instance.mu.Unlock()
-	return instance.GetWordBase(word)
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.GetWordBase(word)
+}
+
+// GetWordBaseWithOptions returns the base word, as GetWordBase does. The options override those of the default
+// stemmer for this call:
+//
+//	rustemmer.GetWordBaseWithOptions("Вагоны", rustemmer.WithCaseFolding())
+func GetWordBaseWithOptions(word string, opts ...Option) string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.with(opts).GetWordBase(word)
+}
+
+// Stem returns the base word. It is the same as GetWordBase: it takes a stemmer from Pool,
+// so it may be called from many goroutines without contention, and it does not allocate
+// for words that need no normalization.
+//
+// Stem and StemRunes do not keep the state of the steps on the stack: they borrow a RuStemmer
+// from Pool instead, so that they run the same steps with the same options, including those set
+// with SetDefault, as GetWordBase. A borrowed stemmer is used by one goroutine at a time,
+// so no lock is taken.
+func Stem(word string) string {
+	return GetWordBase(word)
+}
+
+// StemRunes returns the base of the word as a part of the word, without allocating.
+func StemRunes(word []rune) []rune {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemRunes(word)
+}
+
+// StemBytes returns the base of the UTF-8 encoded word as a part of the word, without allocating.
+func StemBytes(word []byte) []byte {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemBytes(word)
+}
+
+// GetWordBaseInfo returns the base word and reports whether it differs from the word.
+func GetWordBaseInfo(word string) (stem string, changed bool) {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.GetWordBaseInfo(word)
 }
 
 // NormalizeText returns normalized text.
 // Returns text in which all words will be replaced with the basics of words separated by a space.
 // All Special characters except "_" will be removed.
 func NormalizeText(text string) string {
-	instance.mu.Lock()
-	defer instance.mu.Unlock()
-	return instance.NormalizeText(text)
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.NormalizeText(text)
+}
+
+// NormalizeTextWithOptions returns normalized text, as NormalizeText does. The options override those
+// of the default stemmer for this call:
+//
+//	rustemmer.NormalizeTextWithOptions(text, rustemmer.WithRussianStopWords())
+func NormalizeTextWithOptions(text string, opts ...Option) string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.with(opts).NormalizeText(text)
+}
+
+// NormalizeTextSep returns text in which all words are replaced with their bases separated by sep.
+// All Special characters except "_" will be removed.
+func NormalizeTextSep(text, sep string) string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.NormalizeTextSep(text, sep)
+}
+
+// NormalizeTextWith returns text in which all words are replaced with their bases found by s,
+// separated by a space. All Special characters except "_" will be removed.
+func NormalizeTextWith(s WordStemmer, text string) string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.NormalizeTextWith(s, text)
+}
+
+// StemTokens returns the bases of the words, which are split already, in the same order.
+func StemTokens(tokens []string) []string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemTokens(tokens)
+}
+
+// NormalizeWords returns the bases of the words of the text in order of appearance.
+func NormalizeWords(text string) []string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.NormalizeWords(text)
+}
+
+// ForEachStem calls fn with the base of every word of the text in order of appearance,
+// stopping early if fn returns false.
+func ForEachStem(text string, fn func(stem string) bool) {
+	r := getPooled()
+	defer Pool.Put(r)
+	r.ForEachStem(text, fn)
+}
+
+// NormalizeTextPreserve returns text in which every word is replaced with its base in place.
+// All other characters, including punctuation and whitespace, are kept exactly as they were.
+func NormalizeTextPreserve(text string) string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.NormalizeTextPreserve(text)
+}
+
+// OriginalToStem returns a map from each unique word of the text to its base.
+func OriginalToStem(text string) map[string]string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.OriginalToStem(text)
+}
+
+// StemToOriginals returns a map from each base word to the unique words of the text that produce it.
+func StemToOriginals(text string) map[string][]string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemToOriginals(text)
+}
+
+// IsRussianWord reports whether the word contains at least one Cyrillic letter, as HasCyrillic does.
+// Words without Cyrillic letters are returned by GetWordBase without stemming.
+func IsRussianWord(word string) bool {
+	return HasCyrillic(word)
+}
+
+// HasCyrillic reports whether the word contains at least one Cyrillic letter of any language.
+func HasCyrillic(word string) bool {
+	return porter.HasCyrillic(word)
+}
+
+// WordFrequencies returns a map from each base word of the text to the number of its occurrences.
+func WordFrequencies(text string) map[string]int {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.WordFrequencies(text)
+}
+
+// Regions returns the RV, R1 and R2 regions of the word, as defined by Snowball, without removing any suffixes:
+// RV starts after the first vowel, R1 after the first non-vowel following a vowel, and R2 after
+// the first non-vowel following a vowel in R1. All values are rune offsets into the word.
+// An empty region starts at the end of the word.
+func Regions(word string) (rv, r1, r2 int) {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.Regions(word)
+}
+
+// Regions returns the RV, R1 and R2 regions of the word without removing any suffixes, as the package-level Regions does.
+// The word is normalized as GetWordBase normalizes it first, so with case folding the regions of "ГКЧП" are
+// those of "гкчп". All values are rune offsets into the normalized word. An empty region starts at the end of the word.
+func (r *RuStemmer) Regions(word string) (rv, r1, r2 int) {
+	return findRegions([]rune(r.prepareWord(word)))
 }
 
 // GetWordBase returns the base word.
 func (r *RuStemmer) GetWordBase(word string) string {
-	r.word = []rune(word)
-	r.RV = 0
-	r.R2 = 0
-	r.findRegions()
+	return r.getWordBase(word, nil)
+}
+
+// getWordBase returns the base word for GetWordBase and GetWordBaseDetailed. If trace is not nil,
+// the steps that changed the word are recorded in it, and the cache is not read, so that they are run.
+func (r *RuStemmer) getWordBase(word string, trace *[]StepTrace) string {
+	if r.invalidWordPolicy == InvalidWordReplace && r.isInvalidWord(word) {
+		return r.invalidReplacement
+	}
+
+	r.trace = trace
+	base := r.outputBase(word, r.wordBase(word))
+	r.trace = nil
+	if r.stats != nil {
+		r.stats.WordStemmed(utf8.RuneCountInString(base))
+	}
+
+	return base
+}
+
+// wordBase returns the base of the word in the case produced by the configured normalization.
+func (r *RuStemmer) wordBase(word string) string {
+	if r.dictionary != nil {
+		if base, ok := r.dictionary.stems[word]; ok {
+			return base
+		}
+	}
+	if r.isPassedThrough(word) {
+		return word
+	}
+	if r.nonRussianStemmer != nil && !IsRussianWord(word) {
+		return r.nonRussianStemmer.Stem(word)
+	}
+	if r.isStemmedByParts(word) {
+		return r.stemCompound(word, r.compoundMode == CompoundStemParts)
+	}
+
+	// The cache is keyed by the prepared word, so spellings that differ only
+	// in case or "ё" share an entry when the corresponding options are enabled.
+	word = r.prepareWord(word)
+	if base, ok := r.exceptions[word]; ok {
+		return base
+	}
+	if r.lemmas != nil {
+		if lemma, ok := r.lemmas.Lookup(word); ok {
+			return lemma
+		}
+	}
+	if r.cache != nil && r.trace == nil {
+		base, ok := r.cache.get(word)
+		if r.stats != nil {
+			r.stats.CacheLookup(ok)
+		}
+		if ok {
+			return base
+		}
+	}
+
+	base := r.preparedWordBase(word)
+	if r.cache != nil {
+		r.cache.add(word, base)
+	}
+
+	return base
+}
+
+// GetWordBaseInfo returns the base word, like GetWordBase, and reports whether any suffix was removed,
+// that is whether the base differs from the word after the configured normalization, such as case folding.
+func (r *RuStemmer) GetWordBaseInfo(word string) (stem string, changed bool) {
+	if r.isPassedThrough(word) {
+		return r.outputBase(word, word), false
+	}
+
+	stem = r.GetWordBase(word)
+	return stem, stem != r.outputBase(word, r.prepareWord(word))
+}
+
+// Stem returns the base word. It is the same as GetWordBase and implements WordStemmer.
+func (r *RuStemmer) Stem(word string) string {
+	return r.GetWordBase(word)
+}
+
+// StemRunes returns the base of the word. Only the Porter steps and the configured prefix stripping
+// are applied, to the trailing Cyrillic part of the word as GetWordBase does: the words are not normalized
+// and the cache and the dictionary are not consulted. The base is a subslice of the word, so no memory is
+// allocated, unless both a non-Cyrillic head and a prefix are removed, and the word is never modified.
+func (r *RuStemmer) StemRunes(word []rune) []rune {
+	base := r.stemRuneSlice(word)
+	if r.stats != nil {
+		r.stats.WordStemmed(len(base))
+	}
+
+	return base
+}
+
+// stemRuneSlice returns the base of the word for StemRunes.
+func (r *RuStemmer) stemRuneSlice(word []rune) []rune {
+	if len(word) < r.minWordLength {
+		return word
+	}
+
+	head := len(word)
+	for head > 0 && !porter.IsNotCyrillic(word[head - 1]) {
+		head--
+	}
+	if head == len(word) {
+		return word
+	}
+
+	r.word = append(r.word[:0], word[head:]...)
+	start := head + utf8.RuneCountInString(r.stemWord())
+	if start == head {
+		return word[:head + len(r.word)]
+	}
+
+	return append(word[:head:head], word[start:start + len(r.word)]...)
+}
+
+// StemBytes returns the base of the UTF-8 encoded word, as StemRunes does. The base is a subslice of the word,
+// so no memory is allocated, unless both a non-Cyrillic head and a prefix are removed, and the word is never modified.
+// Invalid UTF-8 bytes are kept in the non-Cyrillic head of the word.
+func (r *RuStemmer) StemBytes(word []byte) []byte {
+	base := r.stemByteSlice(word)
+	if r.stats != nil {
+		r.stats.WordStemmed(utf8.RuneCount(base))
+	}
+
+	return base
+}
+
+// stemByteSlice returns the base of the word for StemBytes.
+func (r *RuStemmer) stemByteSlice(word []byte) []byte {
+	if utf8.RuneCount(word) < r.minWordLength {
+		return word
+	}
+
+	head := 0
+	if i := bytes.LastIndexFunc(word, porter.IsNotCyrillic); i >= 0 {
+		_, size := utf8.DecodeRune(word[i:])
+		head = i + size
+	}
+	if head == len(word) {
+		return word
+	}
+
+	r.word = r.word[:0]
+	for i := head; i < len(word); {
+		char, size := utf8.DecodeRune(word[i:])
+		r.word = append(r.word, char)
+		i += size
+	}
+	start := head + len(r.stemWord())
+	end := start + runesLen(r.word)
+	if start == head {
+		return word[:end]
+	}
+
+	return append(word[:head:head], word[start:end]...)
+}
+
+// isPassedThrough reports whether the word is returned as it is, without any preparation.
+func (r *RuStemmer) isPassedThrough(word string) bool {
+	return r.isKeptCompound(word) || r.isProtected(word) ||
+		r.numberPolicy == NumberKeep && isNumber(word) ||
+		r.alphanumericPolicy == NumberKeep && isAlphanumeric(word) ||
+		r.invalidWordPolicy == InvalidWordKeep && r.isInvalidWord(word)
+}
+
+// preparedWordBase returns the base of a word that has already been prepared, without consulting the cache.
+func (r *RuStemmer) preparedWordBase(word string) string {
+	if utf8.RuneCountInString(word) < r.minWordLength {
+		return word
+	}
+
+	return r.stemCyrillicTail(word)
+}
+
+// prepareWord applies the configured Unicode normalization, diacritic stripping, orthography conversion, case folding and "ё" normalization to the word.
+func (r *RuStemmer) prepareWord(word string) string {
+	word = r.normalizeUnicode(word)
+	if r.historicalOrthography {
+		word = modernizeOrthography(word)
+	}
+	if r.caseHandling != CaseOriginal {
+		word = toLower(word)
+	}
+	// The replacer allocates even if there is nothing to replace.
+	if r.yoNormalization && strings.ContainsAny(word, "ёЁ") {
+		word = yoReplacer.Replace(word)
+	}
+
+	return word
+}
+
+// stemCyrillicTail stems the trailing Cyrillic part of the word and keeps the rest of it unchanged,
+// so Latin words and numbers pass through as they are.
+func (r *RuStemmer) stemCyrillicTail(word string) string {
+	head, tail := porter.SplitCyrillicTail(word)
+	if tail == "" {
+		return word
+	}
+
+	start, end := r.stemRunes(tail)
+	if start == 0 {
+		return word[:len(head) + end]
+	}
+
+	return head + tail[start:end]
+}
+
+// stem runs the Porter steps over the word.
+func (r *RuStemmer) stem(word string) string {
+	start, end := r.stemRunes(word)
+	return word[start:end]
+}
+
+// stemRunes runs the Porter steps over the word, leaving the base in r.word.
+// The steps only remove runes from the ends of the word, so the base is also returned
+// as the byte offsets of its start and end in the word, which lets callers slice it out without allocating.
+func (r *RuStemmer) stemRunes(word string) (start, end int) {
+	r.word = r.word[:0]
+	for _, char := range word {
+		r.word = append(r.word, char)
+	}
+
+	prefix := r.stemWord()
+	return len(prefix), len(prefix) + runesLen(r.word)
+}
+
+// stemWord runs the Porter steps over r.word, leaving the base in it,
+// and returns the prefix removed from the start of the word, if any.
+func (r *RuStemmer) stemWord() string {
+	for {
+		length := len(r.word)
+		traced, stepped := r.traceLen(), len(r.steps)
+		if r.stepsVersion == 1 {
+			r.porterStepsV1()
+		} else {
+			r.porterSteps()
+		}
+
+		// Keep the word if the steps leave a base shorter than the minimal stem length.
+		// The steps only shorten the word, so its runes are still in place
+		if len(r.word) < r.minStemLength && len(r.word) < length {
+			r.word = r.word[:length]
+			r.truncateTrace(traced)
+			r.steps = r.steps[:stepped]
+		}
+
+		// With WithIdempotentStems the steps are repeated over the base until they leave it unchanged
+		if !r.idempotentStems || len(r.word) == length {
+			break
+		}
+	}
+
+	// Optionally remove a prefix, which is not part of the Porter algorithm
+	prefix := ""
+	if len(r.prefixes) > 0 {
+		prefix = r.stripPrefix()
+	}
+	if r.stats != nil {
+		r.reportSteps()
+	}
+
+	return prefix
+}
+
+// porterSteps runs the steps of the current version of the Porter algorithm over r.word.
+func (r *RuStemmer) porterSteps() {
+	// All steps work in RV except the derivational step, which works in R2.
+	// R1 is only needed to locate R2.
+	r.RV, r.R1, r.R2 = findRegions(r.word)
 
 	// Step 1
 	// Find ending PERFECTIVE GERUND. If it exists - delete it and complete this step
-	if !r.removeEndings(r.RV, suffixPerfectiveGerunds[0], suffixPerfectiveGerunds[1]) {
+	if !r.applyStep(StepPerfectiveGerund, r.RV, r.suffixPerfectiveGerunds[0], r.suffixPerfectiveGerunds[1]) {
 		// Otherwise, remove ending REFLEXIVE (if it exists)
-		r.removeEndings(r.RV, suffixReflexives)
+		r.applyStep(StepReflexive, r.RV, r.suffixReflexives)
 		// Then try the following procedure to remove ending: ADJECTIVE, VERB, NOUN.
 		// As soon as one of them is found - a step ends
-		ife := r.removeEndings(
+		ife := r.applyStep(
+			StepAdjectival,
 			r.RV,
-			suffixParticiple[0],
-			suffixParticiple[1],
-		) || r.removeEndings(r.RV, suffixAdjective)
+			r.suffixParticiple[0],
+			r.suffixParticiple[1],
+		) || r.applyStep(StepAdjectival, r.RV, r.suffixAdjective)
 
-		if !ife && !r.removeEndings(r.RV, suffixVerb[0], suffixVerb[1]) {
-			r.removeEndings(r.RV, suffixNoun)
+		if !ife && !r.applyStep(StepVerb, r.RV, r.suffixVerb[0], r.suffixVerb[1]) {
+			r.applyStep(StepNoun, r.RV, r.suffixNoun)
 		}
 	}
 
 	// Step 2
 	// If a word ends with "и" - remove the "и"
-	r.removeEndings(r.RV, suffixI)
+	r.applyStep(StepI, r.RV, suffixI)
 
 	// Step 3
 	// If in "R2" there DERIVATIONAL ending - delete it
-	r.removeEndings(r.R2, suffixDerivational)
+	r.applyStep(StepDerivational, r.R2, r.suffixDerivational)
 
 	// Step 4
-	// Possible is one of the three variants:
-	// If a word ending in "нн" - delete the last letter
-	if r.removeEndings(r.RV, suffixNN) {
-		r.word = []rune(string(r.word) + "н")
+	// Possible is one of the three variants, as in the "tidy_up" routine of the Snowball specification
+	// (https://snowballstem.org/algorithms/russian/stemmer.html):
+	// If a word ending in SUPERLATIVE - remove it and then delete the last letter if the word ending in "нн",
+	// so the superlative is removed before "нн" is checked
+	if r.applyStep(StepSuperlative, r.RV, r.suffixSuperlative) {
+		r.undoubleN()
+	} else if !r.undoubleN() {
+		// If a word ending in "ь" - delete it
+		r.applyStep(StepSoftSign, r.RV, suffixSoftSign)
 	}
-
-	// If a word ending in SUPERLATIVE - remove it and remove the last letter again if the word ending in "нн"
-	r.removeEndings(r.RV, suffixSuperlative)
-	// If a word ending in "ь" - delete it
-	r.removeEndings(r.RV, suffixSoftSign)
-
-	return string(r.word)
 }
 
 // NormalizeText returns normalized text.
-// Returns text in which all words will be replaced with the basics of words separated by a space.
+// Returns text in which all words will be replaced with the basics of words separated by a space,
+// or by the separator set with WithOutputSeparator.
 // All Special characters except "_" will be removed.
 func (r *RuStemmer) NormalizeText(text string) string {
-	regexWords := regexp.MustCompile("[\\p{L}\\d_]+")
-	words := regexWords.FindAllString(text, -1)
+	return r.NormalizeTextSep(text, r.separator)
+}
+
+// NormalizeTextSep returns text in which all words are replaced with their bases separated by sep,
+// which overrides the separator set with WithOutputSeparator.
+func (r *RuStemmer) NormalizeTextSep(text, sep string) string {
+	return r.normalizeText(r, text, sep)
+}
+
+// NormalizeTextWith returns text in which all words are replaced with their bases found by s.
+// The text is split into words, filtered and joined as configured for r; only the stemming is done by s.
+func (r *RuStemmer) NormalizeTextWith(s WordStemmer, text string) string {
+	return r.normalizeText(s, text, r.separator)
+}
+
+// StemTokens returns the bases of the words, which are split already, in the same order.
+// The words are not split again, so the output has the same length as the input.
+func (r *RuStemmer) StemTokens(tokens []string) []string {
+	stems := make([]string, len(tokens))
+	for k, token := range tokens {
+		stems[k] = r.GetWordBase(token)
+	}
+
+	return stems
+}
+
+// NormalizeWords returns the bases of the words of the text in order of appearance.
+func (r *RuStemmer) NormalizeWords(text string) []string {
+	stems := []string{}
+	r.ForEachStem(text, func(stem string) bool {
+		stems = append(stems, stem)
+		return true
+	})
+
+	return stems
+}
+
+// ForEachStem calls fn with the base of every word of the text in order of appearance,
+// stopping early if fn returns false. Unlike NormalizeWords, it does not collect the bases,
+// so it suits large texts and searches such as whether a text contains a base.
+func (r *RuStemmer) ForEachStem(text string, fn func(stem string) bool) {
+	for _, loc := range r.findWordIndexes(text) {
+		if !fn(r.GetWordBase(text[loc[0]:loc[1]])) {
+			return
+		}
+	}
+}
+
+// normalizeText returns text in which all words are replaced with their bases found by s, separated by sep.
+func (r *RuStemmer) normalizeText(s WordStemmer, text, sep string) string {
+	words := r.splitWords(text)
 	for k, word := range words {
-		words[k] = r.GetWordBase(word)
+		words[k] = s.Stem(word)
+	}
+
+	return strings.Join(words, sep)
+}
+
+// NormalizeTextPreserve returns text in which every word is replaced with its base in place.
+// All other characters, including punctuation and whitespace, are kept exactly as they were.
+func (r *RuStemmer) NormalizeTextPreserve(text string) string {
+	var buf bytes.Buffer
+	buf.Grow(len(text))
+
+	last := 0
+	for _, loc := range r.findWordIndexes(text) {
+		buf.WriteString(text[last:loc[0]])
+		buf.WriteString(r.GetWordBase(text[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	buf.WriteString(text[last:])
+
+	return buf.String()
+}
+
+// OriginalToStem returns a map from each unique word of the text to its base.
+func (r *RuStemmer) OriginalToStem(text string) map[string]string {
+	ret := map[string]string{}
+	for _, word := range r.splitWords(text) {
+		if _, ok := ret[word]; !ok {
+			ret[word] = r.GetWordBase(word)
+		}
+	}
+
+	return ret
+}
+
+// StemToOriginals returns a map from each base word to the unique words of the text that produce it.
+// The words are listed in order of their first occurrence in the text.
+func (r *RuStemmer) StemToOriginals(text string) map[string][]string {
+	ret := map[string][]string{}
+	seen := map[string]bool{}
+	for _, word := range r.splitWords(text) {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		stem := r.GetWordBase(word)
+		ret[stem] = append(ret[stem], word)
+	}
+
+	return ret
+}
+
+// WordFrequencies returns a map from each base word of the text to the number of its occurrences.
+// Stop words are not counted. The map is empty, but not nil, for a text without words.
+func (r *RuStemmer) WordFrequencies(text string) map[string]int {
+	ret := map[string]int{}
+	for _, word := range r.splitWords(text) {
+		ret[r.GetWordBase(word)]++
+	}
+
+	return ret
+}
+
+// undoubleN deletes the last letter if the word ends in "нн".
+func (r *RuStemmer) undoubleN() bool {
+	if !r.removeEndings(r.RV, suffixNN) {
+		return false
+	}
+	r.word = append(r.word, 'н')
+	r.recordStep(StepNN)
+	r.traceStep(StepNN, "н")
+
+	return true
+}
+
+// applyStep removes the first matching suffix like removeEndings and records the step if tracing.
+func (r *RuStemmer) applyStep(step string, region int, suffixesPacks ...*porter.Trie) bool {
+	length := len(r.word)
+	if !r.removeEndings(region, suffixesPacks...) {
+		return false
+	}
+	r.recordStep(step)
+	if r.trace != nil {
+		r.traceStep(step, string(r.word[len(r.word):length]))
 	}
 
-	return strings.Join(words, " ")
+	return true
 }
 
-func (r *RuStemmer) removeEndings(region int, suffixesPacks ...[]string) bool {
+// removeEndings removes the longest suffix of the tables that lies in the region starting at the rune offset.
+// The regions are found once, before the steps, as in Snowball. The steps only remove suffixes,
+// so the offsets stay valid and are not recomputed; a word shortened to before the start of a region
+// just has the region empty.
+func (r *RuStemmer) removeEndings(region int, suffixesPacks ...*porter.Trie) bool {
 	if region > len(r.word) {
 		region = len(r.word)
 	}
 
-	prefix := r.word[:region]
-	word_ := string(r.word[len(prefix):])
+	word := r.word[region:]
 
-	suffixes := suffixesPacks[0]
+	// Of the two groups the longest matching suffix is removed, as the among of Snowball does, so the first group,
+	// whose suffixes must be preceded by "а" or "я", does not shadow a longer suffix of the second one.
+	n := 0
 	if len(suffixesPacks) == 2 {
-		if result := trimFirstSuffix(word_, suffixes, true); result != word_ {
-			r.word = []rune(string(prefix) + result)
-			return true
-		}
-		suffixes = suffixesPacks[1]
+		n = suffixesPacks[0].Match(word, "ая")
+	}
+	if m := suffixesPacks[len(suffixesPacks) - 1].Match(word, ""); m > n {
+		n = m
+	}
+	if n == 0 {
+		return false
 	}
 
-	if result := trimFirstSuffix(word_, suffixes, false); result != word_ {
-		r.word = []rune(string(prefix) + result)
-		return true
+	r.word = r.word[:len(r.word) - n]
+	return true
+}
+
+// runesLen returns the number of bytes required to encode the runes in UTF-8.
+func runesLen(runes []rune) int {
+	n := 0
+	for _, char := range runes {
+		n += utf8.RuneLen(char)
 	}
 
-	return false
+	return n
 }
 
 func appendPrefix(strs []string, prefixesPacks ...[]string) []string {
@@ -173,47 +927,16 @@ func appendPrefix(strs []string, prefixesPacks ...[]string) []string {
 	return ret
 }
 
-func trimFirstSuffix(word string, suffixes []string, isAYA bool) string {
-	for _, suffix := range suffixes {
-		if isAYA && !(strings.HasSuffix(word, "а" + suffix) || strings.HasSuffix(word, "я" + suffix)) {
-			continue
-		}
-
-		if result := strings.TrimSuffix(word, suffix); result != word {
-			return result
-		}
-	}
-	return word
+// findRegions returns the RV, R1 and R2 regions of the word as rune offsets, as defined by Snowball.
+func findRegions(word []rune) (rv, r1, r2 int) {
+	return porter.Regions(word, VOWEL)
 }
 
-func (r *RuStemmer) findRegions() {
-	state := 0
-	wordLength := len(r.word)
-	for i := 1; i < wordLength; i++ {
-		prevChar := string(r.word[i - 1])
-		char     := string(r.word[i])
-		switch state {
-			case 0:
-				if r.isVowel(char) {
-					r.RV = i + 1
-					state = 1
-				}
-				break
-			case 1:
-				if r.isVowel(prevChar) && !r.isVowel(char) {
-					state = 2
-				}
-				break
-			case 2:
-				if r.isVowel(prevChar) && !r.isVowel(char) {
-					r.R2 = i + 1
-					return
-				}
-				break
-		}
-	}
+// isNumber reports whether the word consists of digits only.
+func isNumber(word string) bool {
+	return word != "" && strings.IndexFunc(word, isNotDigit) < 0
 }
 
-func (r *RuStemmer) isVowel(char string) bool {
-	return strings.Contains(VOWEL, char)
-}
\ No newline at end of file
+func isNotDigit(char rune) bool {
+	return !unicode.IsDigit(char)
+}
@@ -1,6 +1,8 @@
 package rustemmer
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -16,6 +18,31 @@ func BenchmarkNormalizeText(b *testing.B) {
 	}
 }
 
+// longText is a realistic text of more than 500 words.
+var longText = strings.Repeat(
+	"Глава Следственного комитета заявил, что спортсменам могли умышленно подбросить мельдоний. " +
+	"Ограничения на участке Калужско-Рижской линии продлятся до конца недели, поезда будут ходить " +
+	"с увеличенными интервалами, а пассажирам рекомендуют пользоваться наземным транспортом. " +
+	"В вагоне метро заклинило вал, и важнейшие вагоны стояли в тоннеле почти полчаса. ",
+	12,
+)
+
+func BenchmarkNormalizeTextLong(b *testing.B) {
+	b.Run("Pool", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			NormalizeText(longText)
+		}
+	})
+	// Creating a stemmer per text used to compile the tokenizer regular expression every time.
+	b.Run("New", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			New().NormalizeText(longText)
+		}
+	})
+}
+
 func BenchmarkGetWordBase(b *testing.B) {
 	testWords := map[string]string{
 		"результаты"   : "результат",
@@ -81,6 +108,7 @@ func BenchmarkGetWordBase(b *testing.B) {
 		"вами"         : "вам",
 	}
 
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		for word, base := range testWords {
 			testBase := GetWordBase(word)
@@ -89,4 +117,102 @@ func BenchmarkGetWordBase(b *testing.B) {
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+
+func BenchmarkNormalizeTextRepetitive(b *testing.B) {
+	text := strings.Repeat("Важная новость: в вагоне метро заклинило вал, и вагоны стоят. ", 50)
+
+	b.Run("NoCache", func(b *testing.B) {
+		stemmer := New()
+		for i := 0; i < b.N; i++ {
+			stemmer.NormalizeText(text)
+		}
+	})
+	b.Run("Cache", func(b *testing.B) {
+		stemmer := New(WithCache(1000))
+		for i := 0; i < b.N; i++ {
+			stemmer.NormalizeText(text)
+		}
+	})
+}
+
+func BenchmarkRuStemmerGetWordBase(b *testing.B) {
+	words := []string{"результаты", "вагонов", "важнейшими", "валандался", "валериановых", "вальдшнепа"}
+	stemmer := New()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, word := range words {
+			stemmer.GetWordBase(word)
+		}
+	}
+}
+
+func BenchmarkNormalizeTextCacheWarm(b *testing.B) {
+	text := "Результаты проверки города в DB: \"Санкт-Петербурга\" не нашлось! " +
+		"Важная новость (!) В вагоне метро заклинило вал. " +
+		"Глава СКР: спортсменам могли умышленно подбросить мельдоний. " +
+		"Ограничения на участке Калужско-Рижской линии."
+
+	stemmer := New(WithCache(1000))
+	// The first pass fills the cache, so the measured passes are dominated by lookups.
+	stemmer.NormalizeText(text)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		stemmer.NormalizeText(text)
+	}
+	b.StopTimer()
+
+	stats := stemmer.CacheStats()
+	b.ReportMetric(float64(stats.Hits) / float64(stats.Hits + stats.Misses), "hit-ratio")
+}
+
+func BenchmarkStemAppend(b *testing.B) {
+	words := []string{"результаты", "вагонов", "важнейшими", "валандался", "валериановых", "вальдшнепа"}
+	stemmer := New()
+	dst := make([]byte, 0, 64)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, word := range words {
+			dst = stemmer.StemAppend(dst[:0], word)
+		}
+	}
+}
+
+func BenchmarkNormalizeTextParallel(b *testing.B) {
+	text := strings.Repeat("Глава СКР: спортсменам могли умышленно подбросить мельдоний. ", 2000)
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("Workers%d", workers), func(b *testing.B) {
+			stemmer := New()
+			for i := 0; i < b.N; i++ {
+				stemmer.NormalizeTextParallel(text, workers)
+			}
+		})
+	}
+}
+
+func BenchmarkWordFrequencies(b *testing.B) {
+	text := strings.Repeat("Важная новость: в вагоне метро заклинило вал, и вагоны стоят. ", 20)
+	stemmer := New()
+
+	b.Run("WordFrequencies", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			stemmer.WordFrequencies(text)
+		}
+	})
+	b.Run("NormalizeTextFields", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			frequencies := map[string]int{}
+			for _, stem := range strings.Fields(stemmer.NormalizeText(text)) {
+				frequencies[stem]++
+			}
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package rustemmer
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// FuzzGetWordBase checks that GetWordBase never panics and never makes a word longer.
+// Every base is a prefix of the prepared word, as the steps only remove suffixes,
+// and the regions never exceed the word. With WithIdempotentStems stemming is idempotent:
+// the base of a base is the base itself.
+func FuzzGetWordBase(f *testing.F) {
+	seeds := []string{
+		"", "а", "б", "ь", "й", "z", "ннн", "аая", "ьь", "нн", "ейш", "ейшнн",
+		"аеиоуыэюя", "ааяя", "ия",
+		"бвгджзклмнпрстфхцчшщ", "ньь",
+		"вагоны", "ценнейший", "важнейшими", "ВАГОНЫ",
+		"Windows10вагоны", "вагоныWindows", "iPhoneы", "abc", "123",
+		"бой", "\xffвазы", "вазы\xd1", "\u0f75", "\u0344",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	for _, name := range []string{"testdata/stems.txt", "testdata/snowball/voc.txt"} {
+		file, err := os.Open(name)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+				f.Add(fields[0])
+			}
+		}
+		file.Close()
+		if err := scanner.Err(); err != nil {
+			f.Fatal(err)
+		}
+	}
+
+	stemmer, lower := New(), New(WithCaseFolding())
+	stable, stableLower := New(WithIdempotentStems()), New(WithIdempotentStems(), WithCaseFolding())
+	f.Fuzz(func(t *testing.T, word string) {
+		base := stemmer.GetWordBase(word)
+		if utf8.RuneCountInString(base) > utf8.RuneCountInString(word) {
+			t.Errorf("Base %q is longer than the word %q", base, word)
+		}
+		if prepared := stemmer.prepareWord(word); !strings.HasPrefix(prepared, base) {
+			t.Errorf("Base %q is not a prefix of the word %q", base, prepared)
+		}
+		if again := stemmer.GetWordBase(base); !strings.HasPrefix(base, again) {
+			t.Errorf("Base %q of the base %q is not a prefix of it", again, base)
+		}
+
+		if base, prepared := lower.GetWordBase(word), lower.prepareWord(word); !strings.HasPrefix(prepared, base) {
+			t.Errorf("Base %q is not a prefix of the lowercased word %q", base, prepared)
+		}
+
+		for _, s := range []*RuStemmer{stable, stableLower} {
+			base := s.GetWordBase(word)
+			if prepared := s.prepareWord(word); !strings.HasPrefix(prepared, base) {
+				t.Errorf("Base %q is not a prefix of the word %q", base, prepared)
+			}
+			if again := s.GetWordBase(base); again != base {
+				t.Errorf("Not equal: [%s] %s != %s", word, base, again)
+			}
+			if again := s.Stem(s.Stem(word)); again != base {
+				t.Errorf("Not equal: [%s] %s != %s", word, base, again)
+			}
+		}
+
+		length := utf8.RuneCountInString(word)
+		if rv, r1, r2 := Regions(word); rv > length || r1 > length || r2 > length || r1 > r2 {
+			t.Errorf("Invalid regions of %q: %d %d %d", word, rv, r1, r2)
+		}
+	})
+}
+
+// FuzzNormalizeText checks that NormalizeText never panics and replaces every word of the text
+// with a prefix of it, and that NormalizeTextPreserve never makes the text longer.
+// With WithIdempotentStems normalizing a normalized text leaves it unchanged.
+func FuzzNormalizeText(f *testing.F) {
+	seeds := []string{
+		"", " ", "г. Москва, ул. Полярная, д. 31А, стр. 1", "Важные новости!", "вагоны\tстоят\nв депо",
+		"Windows10вагоны iPhoneвазы", "\xffвазы вазы\xd1", "ёлки ЁЛКИ", "е\u0308лки", "0000000\u0344",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	stemmer, stable := New(), New(WithIdempotentStems())
+	f.Fuzz(func(t *testing.T, text string) {
+		words := stemmer.splitWords(text)
+		bases := stemmer.NormalizeWords(text)
+		if len(bases) != len(words) {
+			t.Fatalf("Not equal: %d != %d", len(words), len(bases))
+		}
+		for k, base := range bases {
+			if !strings.HasPrefix(stemmer.prepareWord(words[k]), base) {
+				t.Errorf("Base %q is not a prefix of the word %q", base, words[k])
+			}
+		}
+
+		if normalized := stemmer.NormalizeText(text); normalized != strings.Join(bases, " ") {
+			t.Errorf("Not equal: %q != %q", strings.Join(bases, " "), normalized)
+		}
+		if appended := stemmer.AppendNormalized(nil, text); string(appended) != strings.Join(bases, " ") {
+			t.Errorf("Not equal: %q != %q", strings.Join(bases, " "), appended)
+		}
+		if preserved := stemmer.NormalizeTextPreserve(text); len(preserved) > len(text) {
+			t.Errorf("Text %q is preserved as the longer %q", text, preserved)
+		}
+		if normalized := stable.NormalizeText(text); stable.NormalizeText(normalized) != normalized {
+			t.Errorf("Not equal: %q != %q", normalized, stable.NormalizeText(normalized))
+		}
+	})
+}
@@ -1,8 +1,16 @@
 package rustemmer
 
 import (
+	"bufio"
+	"flag"
+	"os"
 	"testing"
 	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/liderman/rustemmer/internal/porter"
 )
 
 func TestGetWordBase(t *testing.T) {
@@ -76,6 +84,19 @@ func TestGetWordBase(t *testing.T) {
 			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
 		}
 	}
+
+	// The default stemmer follows the whole Snowball vocabulary too, except that it keeps
+	// at least DefaultMinStemLength runes of a word, where Snowball may leave a single one.
+	words := readLines(t, "testdata/snowball/voc.txt")
+	bases := readLines(t, "testdata/snowball/output.txt")
+	for k, word := range words {
+		if len([]rune(bases[k])) < DefaultMinStemLength {
+			continue
+		}
+		if base := GetWordBase(word); base != bases[k] {
+			t.Errorf("Not equal: [%s] %s != %s", word, bases[k], base)
+		}
+	}
 }
 
 func TestNormalizeText(t *testing.T) {
@@ -95,4 +116,726 @@ func TestNormalizeText(t *testing.T) {
 			t.Errorf("Not equal: %s != %s", testText, normalizedText)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestNormalizeTextSep(t *testing.T) {
+	testSeparators := map[string]string{
+		"\n" : "Важн\nновост\nв\nвагон",
+		"\t" : "Важн\tновост\tв\tвагон",
+		""   : "Важнновостввагон",
+	}
+
+	for sep, expected := range testSeparators {
+		if text := NormalizeTextSep("Важная новость: в вагоне", sep); text != expected {
+			t.Errorf("Not equal: %q != %q", expected, text)
+		}
+	}
+
+	stemmer := New(WithOutputSeparator("|"))
+	if text := stemmer.NormalizeTextSep("Важная новость", "\n"); text != "Важн\nновост" {
+		t.Errorf("Not equal: %q != %q", "Важн\nновост", text)
+	}
+	if text := stemmer.NormalizeText("Важная новость"); text != "Важн|новост" {
+		t.Errorf("Not equal: %q != %q", "Важн|новост", text)
+	}
+}
+
+func TestOriginalToStem(t *testing.T) {
+	text := "Важная новость: важные новости, важная новость!"
+	expected := map[string]string{
+		"Важная"  : "Важн",
+		"новость" : "новост",
+		"важные"  : "важн",
+		"новости" : "новост",
+		"важная"  : "важн",
+	}
+
+	result := OriginalToStem(text)
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+
+	if result := OriginalToStem(""); len(result) != 0 {
+		t.Errorf("Expected empty map, got %v", result)
+	}
+}
+
+func TestStemToOriginals(t *testing.T) {
+	text := "Важная новость: важные новости, важная новость!"
+	expected := map[string][]string{
+		"Важн"   : {"Важная"},
+		"важн"   : {"важные", "важная"},
+		"новост" : {"новость", "новости"},
+	}
+
+	result := StemToOriginals(text)
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+}
+
+func TestRegions(t *testing.T) {
+	testWords := map[string][2]int{
+		"вазы"                : {2, 4},
+		"красивый"            : {3, 6},
+		"противоестественном" : {3, 6},
+		"в"                   : {1, 1},
+		"вал"                 : {2, 3},
+		""                    : {0, 0},
+		"армия"               : {1, 5},
+		"армий"               : {1, 5},
+		"война"               : {2, 5},
+		"чайник"              : {2, 6},
+		"майор"               : {2, 5},
+		"йод"                 : {2, 3},
+	}
+
+	stemmer := New()
+	for word, regions := range testWords {
+		rv, _, r2 := stemmer.Regions(word)
+		if rv != regions[0] || r2 != regions[1] {
+			t.Errorf("Not equal: [%s] %v != %v", word, regions, [2]int{rv, r2})
+		}
+	}
+}
+
+func TestNormalizeTextPreserve(t *testing.T) {
+	testTexts := map[string]string{
+		"г. Москва, ул. Полярная" : "г. Москв, ул. Полярн",
+		"вазы"                    : "ваз",
+		"...вазы!?"               : "...ваз!?",
+		"«вазы»\n\tвагоны —вали"  : "«ваз»\n\tвагон —вал",
+		"  Важная  новость  "     : "  Важн  новост  ",
+		"!!! ,,, ..."             : "!!! ,,, ...",
+		""                        : "",
+	}
+
+	for text, testText := range testTexts {
+		normalizedText := NormalizeTextPreserve(text)
+		if !reflect.DeepEqual(testText, normalizedText) {
+			t.Errorf("Not equal: %q != %q", testText, normalizedText)
+		}
+	}
+}
+
+func TestNormalizeTextPreserveSeparators(t *testing.T) {
+	text := "Результаты проверки: «Санкт-Петербурга» — не нашлось!\n"
+	wordRegexp := regexp.MustCompile("[\\p{L}\\d_]+")
+
+	expected := wordRegexp.Split(text, -1)
+	result := wordRegexp.Split(NormalizeTextPreserve(text), -1)
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %q != %q", expected, result)
+	}
+}
+
+func TestStemmerInterface(t *testing.T) {
+	var stemmer Stemmer = New()
+
+	if base := stemmer.GetWordBase("вазы"); base != "ваз" {
+		t.Errorf("Not equal: ваз != %s", base)
+	}
+	if text := stemmer.NormalizeText("Важная новость"); text != "Важн новост" {
+		t.Errorf("Not equal: Важн новост != %s", text)
+	}
+}
+
+func TestGetWordBaseConcurrent(t *testing.T) {
+	testWords := map[string]string{
+		"вазы"     : "ваз",
+		"вагонов"  : "вагон",
+		"важности" : "важност",
+		"валялся"  : "валя",
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				for word, base := range testWords {
+					if testBase := GetWordBase(word); testBase != base {
+						t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPool(t *testing.T) {
+	stemmer := Pool.Get().(*RuStemmer)
+	defer Pool.Put(stemmer)
+
+	if base := stemmer.GetWordBase("вазы"); base != "ваз" {
+		t.Errorf("Not equal: ваз != %s", base)
+	}
+}
+
+func TestGetWordBaseNonCyrillic(t *testing.T) {
+	testWords := map[string]string{
+		"Samsung"    : "Samsung",
+		"Galaxy"     : "Galaxy",
+		"123"        : "123",
+		"v2"         : "v2",
+		"USBшники"   : "USBшник",
+		"31вагонов"  : "31вагон",
+		"купить"     : "куп",
+	}
+
+	for word, base := range testWords {
+		testBase := GetWordBase(word)
+		if !reflect.DeepEqual(base, testBase) {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+}
+
+func TestNormalizeTextNonCyrillic(t *testing.T) {
+	testTexts := map[string]string{
+		"Samsung Galaxy купить" : "Samsung Galaxy куп",
+		"версия v2.0"           : "верс v2 0",
+		"IT-специалисты"        : "IT специалист",
+		"IT-шники"              : "IT шник",
+	}
+
+	for text, testText := range testTexts {
+		normalizedText := NormalizeText(text)
+		if !reflect.DeepEqual(testText, normalizedText) {
+			t.Errorf("Not equal: %s != %s", testText, normalizedText)
+		}
+	}
+
+	if text := New(WithCaseFolding()).NormalizeText("Samsung Galaxy купить"); text != "samsung galaxy куп" {
+		t.Errorf("Not equal: samsung galaxy куп != %s", text)
+	}
+}
+
+func TestIsRussianWord(t *testing.T) {
+	testWords := map[string]bool{
+		"вазы"     : true,
+		"Ёж"       : true,
+		"31А"      : true,
+		"USBшники" : true,
+		"hello"    : false,
+		"123"      : false,
+		"v13pro"   : false,
+		""         : false,
+	}
+
+	for word, expected := range testWords {
+		if result := IsRussianWord(word); result != expected {
+			t.Errorf("Not equal: [%s] %v != %v", word, expected, result)
+		}
+		if result := HasCyrillic(word); result != expected {
+			t.Errorf("Not equal: [%s] %v != %v", word, expected, result)
+		}
+	}
+	if !HasCyrillic("їжак") {
+		t.Error("Expected Cyrillic letters in їжак")
+	}
+}
+
+func TestGetWordBaseGolden(t *testing.T) {
+	file, err := os.Open("testdata/stems.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	stemmer := New()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			t.Fatalf("Malformed line: %q", scanner.Text())
+		}
+		if base := stemmer.GetWordBase(fields[0]); base != fields[1] {
+			t.Errorf("Not equal: [%s] %s != %s", fields[0], fields[1], base)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWordFrequencies(t *testing.T) {
+	text := "Важная новость: важные новости, важная новость в вагоне!"
+	expected := map[string]int{
+		"Важн"   : 1,
+		"важн"   : 2,
+		"новост" : 3,
+		"в"      : 1,
+		"вагон"  : 1,
+	}
+
+	if result := WordFrequencies(text); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+
+	delete(expected, "в")
+	if result := New(WithStopWords([]string{"в"})).WordFrequencies(text); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+
+	if result := WordFrequencies(" ,.! "); result == nil || len(result) != 0 {
+		t.Errorf("Expected empty map, got %v", result)
+	}
+}
+
+func TestFindRegions(t *testing.T) {
+	testWords := map[string][3]int{
+		"противоестественном" : {3, 4, 6},
+		"вазы"                : {2, 3, 4},
+		"армия"               : {1, 2, 5},
+		"августа"             : {1, 2, 5},
+		"еще"                 : {1, 2, 3},
+		"в"                   : {1, 1, 1},
+		""                    : {0, 0, 0},
+		"мост"                : {2, 3, 4},
+		"гость"               : {2, 3, 5},
+		"йогурт"              : {2, 3, 5},
+	}
+
+	for word, regions := range testWords {
+		rv, r1, r2 := Regions(word)
+		if rv != regions[0] || r1 != regions[1] || r2 != regions[2] {
+			t.Errorf("Not equal: [%s] %v != %v", word, regions, [3]int{rv, r1, r2})
+		}
+	}
+
+	// The derivational suffix is removed only in R2, which is empty for short words.
+	testWords2 := map[string]string{
+		"мост"       : "мост",
+		"гость"      : "гост",
+		"злость"     : "злост",
+		"активность" : "активн",
+	}
+	for word, base := range testWords2 {
+		if testBase := GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+}
+
+func TestGetWordBaseRegions(t *testing.T) {
+	// Every step removes a suffix lying entirely in its region, which is measured in runes
+	// of the Cyrillic tail of the word, so characters outside the BMP in its head do not shift it.
+	words := []string{
+		"\U0001F600важность", "ценность\U0001F600ность", "ость", "остость", "ностость", "ценнейшими",
+	}
+	for _, name := range []string{"testdata/snowball/voc.txt", "testdata/stems.txt"} {
+		file, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+				words = append(words, fields[0])
+			}
+		}
+		file.Close()
+	}
+
+	stemmer := New(WithMinStemLength(0))
+	for _, word := range words {
+		_, tail := porter.SplitCyrillicTail(word)
+		rv, _, r2 := Regions(tail)
+		_, trace := stemmer.GetWordBaseTrace(word)
+		for _, step := range trace {
+			region := rv
+			if step.Step == StepDerivational {
+				region = r2
+			}
+			if start := len([]rune(step.Word)); step.Step != StepNN && start < region {
+				t.Errorf("Suffix %s of %s removed at %d before the region %d", step.Suffix, word, start, region)
+			}
+		}
+	}
+}
+
+func TestGetWordBaseSnowball(t *testing.T) {
+	// Expected bases follow the Snowball reference implementation
+	// for words starting with a vowel, where RV begins right after it.
+	testWords := map[string]string{
+		"армия"       : "арм",
+		"армии"       : "арм",
+		"армий"       : "арм",
+		"армию"       : "арм",
+		"армиями"     : "арм",
+		"войнами"     : "войн",
+		"чайника"     : "чайник",
+		"августа"     : "август",
+		"еще"         : "ещ",
+		"окно"        : "окн",
+		"утро"        : "утр",
+		"ученики"     : "ученик",
+		"объявления"  : "объявлен",
+		"организация" : "организац",
+	}
+
+	for word, base := range testWords {
+		testBase := GetWordBase(word)
+		if !reflect.DeepEqual(base, testBase) {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+}
+
+// snowballDeviations lists the words of the Snowball vocabulary whose bases intentionally differ
+// from the reference output, with the reason. The whole vocabulary matches at the moment.
+var snowballDeviations = map[string]string{}
+
+// snowballReport is the file the conformance test writes its mismatches to, for example:
+//
+//	go test -run SnowballConformance -snowball-report=report.tsv
+var snowballReport = flag.String("snowball-report", "", "write the mismatches of the Snowball conformance test to the `file`")
+
+func TestGetWordBaseSnowballConformance(t *testing.T) {
+	// testdata/snowball holds the vocabulary and the output of the Snowball reference implementation
+	// from the russian directory of https://github.com/snowballstem/snowball-data, 49673 words,
+	// one word per line. They are distributed under the license in testdata/snowball/COPYING.
+	words := readLines(t, "testdata/snowball/voc.txt")
+	bases := readLines(t, "testdata/snowball/output.txt")
+	if len(words) != len(bases) {
+		t.Fatalf("Not equal: %d words != %d bases", len(words), len(bases))
+	}
+
+	// Snowball replaces "ё" with "е" before stemming, which this stemmer does only if asked to.
+	stemmer := New(WithAlgorithm(AlgorithmSnowball))
+	mismatches := [][3]string{}
+	for k, word := range words {
+		if reason, ok := snowballDeviations[word]; ok {
+			t.Logf("Skipping %s: %s", word, reason)
+			continue
+		}
+		if base := stemmer.GetWordBase(word); base != bases[k] {
+			mismatches = append(mismatches, [3]string{word, bases[k], base})
+		}
+	}
+
+	// Only the first mismatches are reported as errors, so a regression does not flood the output.
+	for k, mismatch := range mismatches {
+		if k == 20 {
+			t.Errorf("... and %d more", len(mismatches) - k)
+			break
+		}
+		t.Errorf("Not equal: [%s] %s != %s", mismatch[0], mismatch[1], mismatch[2])
+	}
+	t.Logf("%d of %d words differ from the reference output", len(mismatches), len(words))
+
+	if *snowballReport != "" {
+		writeSnowballReport(t, *snowballReport, mismatches)
+	}
+}
+
+// writeSnowballReport writes the mismatches to the file as tab separated values
+// with a header line: the word, the reference base and the base found by the stemmer.
+func writeSnowballReport(t *testing.T, name string, mismatches [][3]string) {
+	var buf strings.Builder
+	buf.WriteString("word\texpected\tgot\n")
+	for _, mismatch := range mismatches {
+		buf.WriteString(strings.Join(mismatch[:], "\t") + "\n")
+	}
+
+	if err := os.WriteFile(name, []byte(buf.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readLines returns the lines of the file.
+func readLines(t *testing.T, name string) []string {
+	file, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return lines
+}
+
+func TestGetWordBaseSuperlativeNN(t *testing.T) {
+	// The superlative is removed before "нн" is undoubled.
+	testWords := map[string]string{
+		"наилучший"     : "наилучш",
+		"наиценнейший"  : "наицен",
+		"наидлиннейший" : "наидлин",
+		"ценнейшими"    : "цен",
+		"длинный"       : "длин",
+		"длинней"       : "длин",
+		"ценность"      : "ценност",
+	}
+
+	for word, base := range testWords {
+		testBase := GetWordBase(word)
+		if !reflect.DeepEqual(base, testBase) {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+}
+
+func TestGetWordBaseAllocs(t *testing.T) {
+	stemmer := New()
+	for _, word := range []string{"важнейшими", "ценнейший", "Windows10-вагоны", "windows10-вагоны"} {
+		allocs := testing.AllocsPerRun(100, func() {
+			stemmer.GetWordBase(word)
+		})
+		if allocs != 0 {
+			t.Errorf("Expected no allocations for %s, got %v", word, allocs)
+		}
+	}
+
+	// Words that the options leave unchanged are not copied.
+	stemmers := []*RuStemmer{New(WithCaseHandling(CasePreserve)), New(WithAlgorithm(AlgorithmSnowball))}
+	for _, stemmer := range stemmers {
+		for _, word := range []string{"важнейшими", "ценнейший", "windows10-вагоны"} {
+			allocs := testing.AllocsPerRun(100, func() {
+				stemmer.GetWordBase(word)
+			})
+			if allocs != 0 {
+				t.Errorf("Expected no allocations for %s, got %v", word, allocs)
+			}
+		}
+	}
+}
+
+func TestGetWordBaseInfo(t *testing.T) {
+	testWords := []struct {
+		word    string
+		stem    string
+		changed bool
+	}{
+		{"вагоны", "вагон", true},
+		{"вагон", "вагон", false},
+		{"ценнейший", "цен", true},
+		{"в", "в", false},
+		{"Windows", "Windows", false},
+		{"", "", false},
+	}
+
+	for _, test := range testWords {
+		stem, changed := GetWordBaseInfo(test.word)
+		if stem != test.stem || changed != test.changed {
+			t.Errorf("Not equal: [%s] %s, %v != %s, %v", test.word, test.stem, test.changed, stem, changed)
+		}
+	}
+
+	stemmer := New(WithCaseFolding(), WithYoNormalization())
+	if stem, changed := stemmer.GetWordBaseInfo("ЁЖ"); stem != "еж" || changed {
+		t.Errorf("Not equal: еж, false != %s, %v", stem, changed)
+	}
+	if stem, changed := stemmer.GetWordBaseInfo("Вагоны"); stem != "вагон" || !changed {
+		t.Errorf("Not equal: вагон, true != %s, %v", stem, changed)
+	}
+}
+
+func TestGetWordBaseLongestSuffix(t *testing.T) {
+	// The endings overlap with shorter ones, which must not be removed instead.
+	testWords := map[string]string{
+		"армиями"      : "арм",
+		"армиях"       : "арм",
+		"линией"       : "лин",
+		"зданием"      : "здан",
+		"станции"      : "станц",
+		"вагонами"     : "вагон",
+		"сделавшись"   : "сдела",
+		"важнейше"     : "важн",
+		"бдительность" : "бдительн",
+	}
+
+	for word, base := range testWords {
+		if testBase := GetWordBase(word); base != testBase {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+}
+
+func TestForEachStem(t *testing.T) {
+	text := "Важная новость: в вагоне метро заклинило вал!"
+	expected := []string{"Важн", "новост", "в", "вагон", "метр", "заклин", "вал"}
+
+	if stems := NormalizeWords(text); !reflect.DeepEqual(expected, stems) {
+		t.Errorf("Not equal: %v != %v", expected, stems)
+	}
+	if stems := NormalizeWords(" ,.! "); len(stems) != 0 {
+		t.Errorf("Expected no stems, got %v", stems)
+	}
+
+	stems := []string{}
+	ForEachStem(text, func(stem string) bool {
+		stems = append(stems, stem)
+		return true
+	})
+	if !reflect.DeepEqual(expected, stems) {
+		t.Errorf("Not equal: %v != %v", expected, stems)
+	}
+
+	stems = []string{}
+	ForEachStem(text, func(stem string) bool {
+		stems = append(stems, stem)
+		return stem != "вагон"
+	})
+	if !reflect.DeepEqual(expected[:4], stems) {
+		t.Errorf("Not equal: %v != %v", expected[:4], stems)
+	}
+}
+
+func TestNormalizeTextReentrant(t *testing.T) {
+	// Calls nested in a single goroutine must not deadlock or corrupt the state of the outer call.
+	var nested []string
+	stemmer := New(WithTokenizer(TokenizerFunc(func(text string) [][]int {
+		nested = append(nested, NormalizeText(text))
+		return wordTokenizer.WordIndexes(text)
+	})))
+
+	text := "Важная новость в вагоне"
+	if normalized := stemmer.NormalizeText(text); normalized != "Важн новост в вагон" {
+		t.Errorf("Not equal: %s != %s", "Важн новост в вагон", normalized)
+	}
+	if !reflect.DeepEqual([]string{"Важн новост в вагон"}, nested) {
+		t.Errorf("Not equal: %v != %v", []string{"Важн новост в вагон"}, nested)
+	}
+
+	stems := []string{}
+	stemmer = New()
+	stemmer.ForEachStem(text, func(stem string) bool {
+		stems = append(stems, stem + ":" + stemmer.GetWordBase("вазы"))
+		return true
+	})
+	expected := []string{"Важн:ваз", "новост:ваз", "в:ваз", "вагон:ваз"}
+	if !reflect.DeepEqual(expected, stems) {
+		t.Errorf("Not equal: %v != %v", expected, stems)
+	}
+}
+
+func TestGetWordBaseWithoutVowels(t *testing.T) {
+	// Without a vowel RV is empty, so nothing is removed.
+	testWords := map[string]string{
+		"гкчп" : "гкчп",
+		"вкл"  : "вкл",
+		"ь"    : "ь",
+		"сь"   : "сь",
+		"тсс"  : "тсс",
+		"а"    : "а",
+		"я"    : "я",
+		"мгу"  : "мгу",
+		"мгла" : "мгла",
+		"ая"   : "ая",
+	}
+
+	stemmer := New(WithCaseFolding())
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); base != testBase {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+	if base := stemmer.GetWordBase("ГКЧП"); base != "гкчп" {
+		t.Errorf("Not equal: %s != %s", "гкчп", base)
+	}
+	if rv, r1, r2 := stemmer.Regions("ГКЧП"); rv != 4 || r1 != 4 || r2 != 4 {
+		t.Errorf("Not equal: [4 4 4] != [%d %d %d]", rv, r1, r2)
+	}
+
+	// Words are normalized as GetWordBase normalizes them, so upper case vowels are found only with case folding.
+	if rv, r1, r2 := stemmer.Regions("ВАЗЫ"); rv != 2 || r1 != 3 || r2 != 4 {
+		t.Errorf("Not equal: [2 3 4] != [%d %d %d]", rv, r1, r2)
+	}
+	if rv, r1, r2 := Regions("ВАЗЫ"); rv != 4 || r1 != 4 || r2 != 4 {
+		t.Errorf("Not equal: [4 4 4] != [%d %d %d]", rv, r1, r2)
+	}
+}
+
+func TestStemTokens(t *testing.T) {
+	tokens := []string{"вагоны", "Wi-Fi", "", "важнейшими", "вагоны", "👍вазы", "в"}
+	stems := StemTokens(tokens)
+	if len(stems) != len(tokens) {
+		t.Fatalf("Not equal: %d != %d", len(tokens), len(stems))
+	}
+	for k, token := range tokens {
+		if base := GetWordBase(token); stems[k] != base {
+			t.Errorf("Not equal: [%s] %s != %s", token, base, stems[k])
+		}
+	}
+
+	expected := []string{"вагон", "Wi-Fi", "", "важн", "вагон", "👍ваз", "в"}
+	if !reflect.DeepEqual(expected, stems) {
+		t.Errorf("Not equal: %v != %v", expected, stems)
+	}
+	if stems := StemTokens(nil); len(stems) != 0 {
+		t.Errorf("Expected no stems, got %v", stems)
+	}
+}
+
+func TestStemRunes(t *testing.T) {
+	testWords := []string{"вагоны", "важнейшими", "ценнейший", "в", "", "Windows", "Windows10вагоны", "👍вазы"}
+	for _, word := range testWords {
+		runes := []rune(word)
+		if base := string(StemRunes(runes)); base != GetWordBase(word) {
+			t.Errorf("Not equal: [%s] %s != %s", word, GetWordBase(word), base)
+		}
+		if string(runes) != word {
+			t.Errorf("The word %s was modified to %s", word, string(runes))
+		}
+		if base := Stem(word); base != GetWordBase(word) {
+			t.Errorf("Not equal: [%s] %s != %s", word, GetWordBase(word), base)
+		}
+	}
+
+	stemmer := New(WithPrefixStripping([]string{"пере"}))
+	testWords = []string{"переписать", "Wi-Fiпереписать"}
+	for _, word := range testWords {
+		if base := string(stemmer.StemRunes([]rune(word))); base != stemmer.GetWordBase(word) {
+			t.Errorf("Not equal: [%s] %s != %s", word, stemmer.GetWordBase(word), base)
+		}
+	}
+
+	runes := []rune("важнейшими")
+	allocs := testing.AllocsPerRun(100, func() {
+		StemRunes(runes)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func TestStemBytes(t *testing.T) {
+	testWords := []string{"вагоны", "важнейшими", "ценнейший", "в", "", "Windows", "Windows10вагоны", "👍вазы", "\xffвазы", "вазы\xff"}
+	for _, word := range testWords {
+		b := []byte(word)
+		if base := string(StemBytes(b)); base != GetWordBase(word) {
+			t.Errorf("Not equal: [%q] %q != %q", word, GetWordBase(word), base)
+		}
+		if string(b) != word {
+			t.Errorf("The word %s was modified to %s", word, string(b))
+		}
+	}
+
+	stemmer := New(WithPrefixStripping([]string{"пере"}), WithMinWordLength(3))
+	testWords = []string{"переписать", "Wi-Fiпереписать", "вы"}
+	for _, word := range testWords {
+		if base := string(stemmer.StemBytes([]byte(word))); base != stemmer.GetWordBase(word) {
+			t.Errorf("Not equal: [%s] %s != %s", word, stemmer.GetWordBase(word), base)
+		}
+	}
+
+	b := []byte("важнейшими")
+	stemmer = New()
+	allocs := testing.AllocsPerRun(100, func() {
+		stemmer.StemBytes(b)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
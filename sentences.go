@@ -0,0 +1,107 @@
+package rustemmer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// prefixAbbreviations lists the abbreviations, in lower case and without the final period, that are followed
+// by the word they qualify, such as "г. Москва" or "ул. Полярная", so a period after them never ends a sentence.
+var prefixAbbreviations = stopWordSet([]string{
+	"г", "гг", "ул", "д", "стр", "кв", "корп", "пр", "просп", "пер", "пл", "наб", "ш", "пос", "с", "дер", "обл",
+	"им", "св", "тов", "гр", "проф", "акад", "доц", "ген", "см", "рис", "табл", "гл", "п", "пп", "ст", "тел", "т", "тт",
+	"ок", "напр", "ср", "т.е", "т.к", "т.н", "т.ч", "н.э",
+})
+
+// Sentences splits the text into sentences, trimmed of the surrounding white space.
+// A sentence ends with ".", "!", "?" or "…", possibly repeated and followed by closing quotes or brackets,
+// when the next sentence starts after white space with anything but a lower case letter.
+// A period does not end a sentence after the common abbreviations preceding a name or a number,
+// as in "г. Москва, ул. Полярная, д. 31А", or after an initial, as in "А.С. Пушкин" or "А. С. Пушкин",
+// while "т. д." and "т. п." end one if the next word is capitalized.
+func Sentences(text string) []string {
+	var sentences []string
+	start := 0
+	for i := 0; i < len(text); {
+		pos := i
+		char, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		if !isSentenceEnd(char) {
+			continue
+		}
+
+		end := i
+		for end < len(text) {
+			next, size := utf8.DecodeRuneInString(text[end:])
+			if !isSentenceEnd(next) && !isClosingMark(next) {
+				break
+			}
+			end += size
+		}
+		i = end
+
+		next := len(text) - len(strings.TrimLeftFunc(text[end:], unicode.IsSpace))
+		if next < len(text) && (next == end || !startsSentence(text[next:])) {
+			continue
+		}
+		// A single period may follow an abbreviation, while an ellipsis "..." always ends a sentence.
+		if char == '.' && !strings.HasPrefix(text[pos:], "..") && !endsWithPeriod(text[:pos]) {
+			continue
+		}
+
+		if sentence := strings.TrimSpace(text[start:end]); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+	}
+	if sentence := strings.TrimSpace(text[start:]); sentence != "" {
+		sentences = append(sentences, sentence)
+	}
+
+	return sentences
+}
+
+// endsWithPeriod reports whether a period following the text ends a sentence,
+// that is the text does not end with an abbreviation or an initial.
+func endsWithPeriod(text string) bool {
+	word := text[len(strings.TrimRightFunc(text, unicode.IsLetter)):]
+	if word == "" {
+		return true
+	}
+
+	// Join abbreviations written with spaces, such as "т. д.", to look them up as "т.д".
+	abbreviation := word
+	if rest := text[:len(text) - len(word)]; strings.HasSuffix(rest, ". ") || strings.HasSuffix(rest, ".") {
+		rest = strings.TrimSuffix(strings.TrimSuffix(rest, " "), ".")
+		if prev := rest[len(strings.TrimRightFunc(rest, unicode.IsLetter)):]; prev != "" {
+			abbreviation = prev + "." + word
+		}
+	}
+	abbreviation = toLower(abbreviation)
+	if abbreviation == "т.д" || abbreviation == "т.п" {
+		return true
+	}
+
+	first, size := utf8.DecodeRuneInString(word)
+	if size == len(word) && unicode.IsUpper(first) {
+		return false
+	}
+
+	return !prefixAbbreviations[abbreviation] && !prefixAbbreviations[toLower(word)]
+}
+
+// startsSentence reports whether the text may be the start of a sentence, that is it does not start with a lower case letter.
+func startsSentence(text string) bool {
+	char, _ := utf8.DecodeRuneInString(text)
+	return !unicode.IsLower(char)
+}
+
+func isSentenceEnd(char rune) bool {
+	return char == '.' || char == '!' || char == '?' || char == '…'
+}
+
+// isClosingMark reports whether the character is a closing quote or bracket, which belongs to the sentence before it.
+func isClosingMark(char rune) bool {
+	return char == '»' || char == '"' || char == '”' || char == '\'' || char == ')' || char == ']'
+}
@@ -0,0 +1,42 @@
+package rustemmer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSentences(t *testing.T) {
+	testTexts := map[string][]string{
+		"" : nil,
+		"  " : nil,
+		"Вагоны стоят в депо" : {"Вагоны стоят в депо"},
+		"Вагоны стоят. Поезда идут!  Почему? " : {"Вагоны стоят.", "Поезда идут!", "Почему?"},
+		"Офис: г. Москва, ул. Полярная, д. 31А, стр. 1. Вход со двора." : {
+			"Офис: г. Москва, ул. Полярная, д. 31А, стр. 1.",
+			"Вход со двора.",
+		},
+		"Автор — А.С. Пушкин. Поэма — «Руслан и Людмила» А. С. Пушкина." : {
+			"Автор — А.С. Пушкин.",
+			"Поэма — «Руслан и Людмила» А. С. Пушкина.",
+		},
+		"Яблоки, груши и т.д. Всё свежее. Овощи, зелень и т. п. Тоже." : {
+			"Яблоки, груши и т.д.",
+			"Всё свежее.",
+			"Овощи, зелень и т. п.",
+			"Тоже.",
+		},
+		"Он сказал: «Стой!» Все замерли... Потом?! Тишина…" : {
+			"Он сказал: «Стой!»",
+			"Все замерли...",
+			"Потом?!",
+			"Тишина…",
+		},
+		"Число 3.14 и т.е. пример. в тексте, см. рис. 5" : {"Число 3.14 и т.е. пример. в тексте, см. рис. 5"},
+	}
+
+	for text, expected := range testTexts {
+		if sentences := Sentences(text); !reflect.DeepEqual(sentences, expected) {
+			t.Errorf("Not equal: %q != %q", expected, sentences)
+		}
+	}
+}
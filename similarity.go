@@ -0,0 +1,114 @@
+package rustemmer
+
+import (
+	"strings"
+)
+
+// SameStem reports whether the two words have the same base, ignoring surrounding whitespace.
+func SameStem(a, b string) bool {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.SameStem(a, b)
+}
+
+// SameLexeme reports whether the two words or phrases differ only by inflection, case and "ё".
+func SameLexeme(a, b string) bool {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.SameLexeme(a, b)
+}
+
+// LexemeKey returns the key shared by the forms of the word or phrase, for use as a map key.
+func LexemeKey(text string) string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.LexemeKey(text)
+}
+
+// StemSimilarity reports whether the two words have the same base.
+func StemSimilarity(a, b string) bool {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemSimilarity(a, b)
+}
+
+// StemDistance returns the Levenshtein distance between the bases of the two words.
+func StemDistance(a, b string) int {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemDistance(a, b)
+}
+
+// StemSimilarity reports whether the two words have the same base.
+func (r *RuStemmer) StemSimilarity(a, b string) bool {
+	return r.GetWordBase(a) == r.GetWordBase(b)
+}
+
+// SameStem reports whether the two words have the same base, ignoring surrounding whitespace.
+// The words are compared after the configured normalization, so with WithCaseFolding
+// SameStem("Ваза", "вазы") is true. Words that are equal after normalization are not stemmed.
+func (r *RuStemmer) SameStem(a, b string) bool {
+	a = r.prepareWord(strings.TrimSpace(a))
+	b = r.prepareWord(strings.TrimSpace(b))
+	if a == b {
+		return true
+	}
+
+	return r.GetWordBase(a) == r.GetWordBase(b)
+}
+
+// SameLexeme reports whether the two words or phrases have the same key, as returned by LexemeKey,
+// so "Зелёный чай" and "зеленого чая" are the same, while "вазы" and "вагоны" are not.
+func (r *RuStemmer) SameLexeme(a, b string) bool {
+	return r.LexemeKey(a) == r.LexemeKey(b)
+}
+
+// LexemeKey returns the bases of the words of the text separated by spaces, found after the text
+// is converted to lower case and "ё" is replaced with "е", whatever the options of the stemmer.
+// The forms of a word or phrase that differ by case, number or gender share the key, so it suits
+// grouping names, such as product names, that differ only by inflection. Punctuation and, if configured,
+// stop words are skipped.
+func (r *RuStemmer) LexemeKey(text string) string {
+	return strings.Join(r.NormalizeWords(yoReplacer.Replace(toLower(text))), " ")
+}
+
+// StemDistance returns the Levenshtein distance between the bases of the two words.
+// The distance is measured in runes.
+func (r *RuStemmer) StemDistance(a, b string) int {
+	return levenshtein([]rune(r.GetWordBase(a)), []rune(r.GetWordBase(b)))
+}
+
+// levenshtein returns the minimal number of single rune insertions, deletions
+// and substitutions required to turn a into b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b) + 1)
+	curr := make([]int, len(b) + 1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i - 1] == b[j - 1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j] + 1, curr[j - 1] + 1, prev[j - 1] + cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	ret := values[0]
+	for _, value := range values[1:] {
+		if value < ret {
+			ret = value
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,113 @@
+package rustemmer
+
+import (
+	"testing"
+)
+
+func TestStemSimilarity(t *testing.T) {
+	testPairs := map[[2]string]bool{
+		{"вагон", "вагонами"} : true,
+		{"важная", "важными"} : true,
+		{"вазы", "вазах"}     : true,
+		{"вазы", "вагоны"}    : false,
+		{"Важная", "важная"}  : false,
+		{"", ""}              : true,
+	}
+
+	for pair, expected := range testPairs {
+		if result := StemSimilarity(pair[0], pair[1]); result != expected {
+			t.Errorf("Not equal: %v %v != %v", pair, expected, result)
+		}
+	}
+
+	if !New(WithCaseFolding()).StemSimilarity("Важная", "важная") {
+		t.Errorf("Expected words to be similar with case folding")
+	}
+}
+
+func TestSameStem(t *testing.T) {
+	testPairs := map[[2]string]bool{
+		{"вагон", "вагон"}      : true,
+		{"вагон", " вагонами "} : true,
+		{"вазы", "вагоны"}      : false,
+		{"Ваза", "вазы"}        : false,
+		{"ёлка", "елки"}        : false,
+		{"", ""}                : true,
+		{"", " "}               : true,
+		{"", "вагон"}           : false,
+	}
+
+	for pair, expected := range testPairs {
+		if result := SameStem(pair[0], pair[1]); result != expected {
+			t.Errorf("Not equal: %v %v != %v", pair, expected, result)
+		}
+	}
+
+	stemmer := New(WithCaseFolding(), WithYoNormalization())
+	testPairs = map[[2]string]bool{
+		{"Ваза", "вазы"}    : true,
+		{"ВАГОН", "вагон"}  : true,
+		{"Ёлка", "елки"}    : true,
+		{"Ваза", "Вагоны"}  : false,
+		{"\tВаза\n", "ваз"} : true,
+	}
+
+	for pair, expected := range testPairs {
+		if result := stemmer.SameStem(pair[0], pair[1]); result != expected {
+			t.Errorf("Not equal: %v %v != %v", pair, expected, result)
+		}
+	}
+}
+
+func TestStemDistance(t *testing.T) {
+	testPairs := map[[2]string]int{
+		{"вагон", "вагонами"}  : 0,
+		{"вазы", "вагоны"}     : 3,
+		{"вал", "вальс"}       : 2,
+		{"важности", "важная"} : 3,
+		{"", "вазы"}           : 3,
+		{"", ""}               : 0,
+	}
+
+	for pair, expected := range testPairs {
+		if result := StemDistance(pair[0], pair[1]); result != expected {
+			t.Errorf("Not equal: %v %d != %d", pair, expected, result)
+		}
+		if result := StemDistance(pair[1], pair[0]); result != expected {
+			t.Errorf("Not equal: %v %d != %d", pair, expected, result)
+		}
+	}
+}
+
+func TestSameLexeme(t *testing.T) {
+	testPairs := map[[2]string]bool{
+		{"Зелёный чай", "зеленого чая"}     : true,
+		{"ЁЛКА", "елки"}                    : true,
+		{"Красная ваза", "красные вазы!"}   : true,
+		{"вазы", "вагоны"}                  : false,
+		{"зелёный чай", "чай зелёный"}      : false,
+		{"", " , "}                         : true,
+	}
+
+	for pair, expected := range testPairs {
+		if result := SameLexeme(pair[0], pair[1]); result != expected {
+			t.Errorf("Not equal: %v %v != %v", pair, expected, result)
+		}
+	}
+
+	testKeys := map[string]string{
+		"Зелёного чая"  : "зелен ча",
+		"ВАГОНЫ"        : "вагон",
+		"  "            : "",
+	}
+	for text, expected := range testKeys {
+		if key := LexemeKey(text); key != expected {
+			t.Errorf("Not equal: %s != %s", expected, key)
+		}
+	}
+
+	stemmer := New(WithCaseHandling(CasePreserve), WithOutputSeparator("_"), WithRussianStopWords())
+	if key := stemmer.LexemeKey("Чай с лимоном"); key != "ча лимон" {
+		t.Errorf("Not equal: ча лимон != %s", key)
+	}
+}
@@ -0,0 +1,359 @@
+package rustemmer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/liderman/rustemmer/internal/porter"
+)
+
+// snapshotVersion is the version of the format written by MarshalBinary.
+// It must be increased whenever the format changes.
+const snapshotVersion = 3
+
+// ErrSnapshotVersion is returned by UnmarshalBinary for snapshots written in an unsupported format.
+var ErrSnapshotVersion = errors.New("rustemmer: unsupported snapshot version")
+
+// ErrNotSerializable is returned by MarshalBinary for stemmers configured with Go values, such as functions,
+// that cannot be written.
+var ErrNotSerializable = errors.New("rustemmer: stemmer cannot be serialized with")
+
+// errInvalidSnapshot is returned by UnmarshalBinary for snapshots with inconsistent data.
+var errInvalidSnapshot = errors.New("rustemmer: invalid snapshot")
+
+var _ encoding.BinaryMarshaler = (*RuStemmer)(nil)
+var _ encoding.BinaryUnmarshaler = (*RuStemmer)(nil)
+
+// MarshalBinary returns a snapshot of the configuration of the stemmer: its options, stop words, exceptions,
+// protected words, dictionaries, suffix tables and prefixes. UnmarshalBinary restores the stemmer from it
+// without parsing the dictionaries or sorting the tables again, which suits loading a large configuration
+// at the start of a service. The contents of the cache are not saved, only its size.
+// It returns an error wrapping ErrNotSerializable if the stemmer has a tokenizer other than one set
+// with WithTokenizerPattern or WithCompoundWordSplitting, a stemmer for non-Russian words, an output
+// transformation or a statistics observer.
+func (r *RuStemmer) MarshalBinary() ([]byte, error) {
+	pattern, ok := r.tokenizerPattern()
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%w %s", ErrNotSerializable, "a custom tokenizer")
+	case r.nonRussianStemmer != nil:
+		return nil, fmt.Errorf("%w %s", ErrNotSerializable, "WithNonRussianStemmer")
+	case r.outputTransform != nil:
+		return nil, fmt.Errorf("%w %s", ErrNotSerializable, "WithOutputTransform")
+	case r.stats != nil:
+		return nil, fmt.Errorf("%w %s", ErrNotSerializable, "WithStats")
+	}
+
+	var data bytes.Buffer
+	w := bufio.NewWriter(&data)
+	w.WriteByte(snapshotVersion)
+	for _, flag := range []bool{
+		r.unicodeNormalization, r.diacriticStripping, r.historicalOrthography, r.yoNormalization,
+		r.dropNonRussian, r.abbreviationDetection, r.idempotentStems,
+	} {
+		writeBool(w, flag)
+	}
+	for _, n := range []int{
+		int(r.caseHandling), r.minWordLength, r.minStemLength, int(r.numberPolicy), int(r.alphanumericPolicy),
+		int(r.compoundMode), r.cacheSize(), int(r.invalidWordPolicy), r.stepsVersion,
+	} {
+		writeVarint(w, int64(n))
+	}
+	writeString(w, r.invalidReplacement)
+	writeString(w, r.separator)
+	writeString(w, pattern)
+
+	writeStrings(w, sortedKeys(r.stopWords))
+	writeStrings(w, sortedKeys(r.protectedWords))
+	exceptions := make([]string, 0, len(r.exceptions))
+	for word := range r.exceptions {
+		exceptions = append(exceptions, word)
+	}
+	sort.Strings(exceptions)
+	writeUvarint(w, uint64(len(exceptions)))
+	for _, word := range exceptions {
+		writeString(w, word)
+		writeString(w, r.exceptions[word])
+	}
+
+	writeBool(w, r.dictionary != nil)
+	if r.dictionary != nil {
+		r.dictionary.Save(w)
+	}
+	writeBool(w, r.lemmas != nil)
+	if r.lemmas != nil {
+		r.lemmas.writeTo(w)
+	}
+
+	for _, trie := range r.suffixTries() {
+		writeStrings(w, (*trie).Suffixes())
+	}
+	writeStrings(w, r.prefixes)
+
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+
+	return data.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the configuration of the stemmer with the snapshot written by MarshalBinary.
+// The cache, if any, starts empty.
+func (r *RuStemmer) UnmarshalBinary(data []byte) error {
+	s := &snapshotReader{r: bufio.NewReader(bytes.NewReader(data))}
+	version, err := s.r.ReadByte()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if version != snapshotVersion {
+		return fmt.Errorf("%w %d", ErrSnapshotVersion, version)
+	}
+
+	c := New()
+	for _, flag := range []*bool{
+		&c.unicodeNormalization, &c.diacriticStripping, &c.historicalOrthography, &c.yoNormalization,
+		&c.dropNonRussian, &c.abbreviationDetection, &c.idempotentStems,
+	} {
+		*flag = s.bool()
+	}
+	c.caseHandling = CaseHandling(s.int())
+	c.minWordLength = s.int()
+	c.minStemLength = s.int()
+	c.numberPolicy = NumberPolicy(s.int())
+	c.alphanumericPolicy = NumberPolicy(s.int())
+	c.compoundMode = CompoundMode(s.int())
+	if size := s.int(); size > 0 {
+		c.cache = newStemCache(size)
+	}
+	c.invalidWordPolicy = InvalidWordPolicy(s.int())
+	c.stepsVersion = s.int()
+	c.invalidReplacement = s.string()
+	c.separator = s.string()
+	pattern := s.string()
+
+	c.stopWords = stringSet(s.strings())
+	c.protectedWords = stringSet(s.strings())
+	if n := s.uint(); n > 0 && s.err == nil {
+		c.exceptions = map[string]string{}
+		for i := uint64(0); i < n && s.err == nil; i++ {
+			word := s.string()
+			c.exceptions[word] = s.string()
+		}
+	}
+
+	if s.bool() && s.err == nil {
+		c.dictionary, s.err = LoadDictionary(s.r)
+	}
+	if s.bool() && s.err == nil {
+		c.lemmas, s.err = readLemmaDict(s.r)
+	}
+
+	for _, trie := range c.suffixTries() {
+		*trie = porter.NewTrie(s.strings())
+	}
+	c.prefixes = s.strings()
+	if s.err != nil {
+		return s.err
+	}
+
+	switch pattern {
+	case wordPattern:
+		c.tokenizer = wordTokenizer
+	case compoundWordPattern:
+		c.tokenizer = compoundWordTokenizer
+	default:
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+		c.tokenizer = regexpTokenizer{re}
+	}
+
+	*r = *c
+	return nil
+}
+
+// tokenizerPattern returns the regular expression of the tokenizer and reports whether the tokenizer is based on one.
+func (r *RuStemmer) tokenizerPattern() (string, bool) {
+	t, ok := r.tokenizer.(regexpTokenizer)
+	if !ok {
+		return "", false
+	}
+
+	return t.re.String(), true
+}
+
+// cacheSize returns the size of the cache, or 0 if it is not enabled.
+func (r *RuStemmer) cacheSize() int {
+	if r.cache == nil {
+		return 0
+	}
+
+	return r.cache.size
+}
+
+// suffixTries returns pointers to the suffix tries of the stemmer in the order they are written to a snapshot.
+func (r *RuStemmer) suffixTries() []**porter.Trie {
+	return []**porter.Trie{
+		&r.suffixPerfectiveGerunds[0], &r.suffixPerfectiveGerunds[1], &r.suffixReflexives, &r.suffixAdjective,
+		&r.suffixParticiple[0], &r.suffixParticiple[1], &r.suffixVerb[0], &r.suffixVerb[1], &r.suffixNoun,
+		&r.suffixSuperlative, &r.suffixDerivational,
+	}
+}
+
+// writeTo writes the strings and offsets of the dictionary, as they are kept in memory, for readLemmaDict.
+func (d *LemmaDict) writeTo(w *bufio.Writer) {
+	writeString(w, d.forms)
+	writeString(w, d.lemmas)
+	for _, offsets := range [][]uint32{d.formOffsets, d.formLemmas, d.lemmaOffsets} {
+		writeUvarint(w, uint64(len(offsets)))
+		for _, offset := range offsets {
+			writeUvarint(w, uint64(offset))
+		}
+	}
+}
+
+// readLemmaDict reads a dictionary written by LemmaDict.writeTo.
+func readLemmaDict(r *bufio.Reader) (*LemmaDict, error) {
+	s := &snapshotReader{r: r}
+	d := &LemmaDict{forms: s.string(), lemmas: s.string()}
+	for _, offsets := range []*[]uint32{&d.formOffsets, &d.formLemmas, &d.lemmaOffsets} {
+		n := s.uint()
+		for i := uint64(0); i < n && s.err == nil; i++ {
+			*offsets = append(*offsets, uint32(s.uint()))
+		}
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if len(d.formOffsets) != len(d.formLemmas) + 1 || len(d.lemmaOffsets) == 0 {
+		return nil, errInvalidSnapshot
+	}
+	for k, index := range d.formLemmas {
+		if int(index) + 1 >= len(d.lemmaOffsets) || d.formOffsets[k] > d.formOffsets[k + 1] {
+			return nil, errInvalidSnapshot
+		}
+	}
+	if int(d.formOffsets[len(d.formOffsets) - 1]) > len(d.forms) {
+		return nil, errInvalidSnapshot
+	}
+	for k := 1; k < len(d.lemmaOffsets); k++ {
+		if d.lemmaOffsets[k - 1] > d.lemmaOffsets[k] || int(d.lemmaOffsets[k]) > len(d.lemmas) {
+			return nil, errInvalidSnapshot
+		}
+	}
+
+	return d, nil
+}
+
+// sortedKeys returns the words of the set in alphabetical order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// stringSet returns the set of the strings, or nil if there are none.
+func stringSet(strs []string) map[string]bool {
+	if len(strs) == 0 {
+		return nil
+	}
+
+	set := make(map[string]bool, len(strs))
+	for _, str := range strs {
+		set[str] = true
+	}
+
+	return set
+}
+
+// writeBool writes b as a byte. Errors are reported by w.Flush.
+func writeBool(w *bufio.Writer, b bool) {
+	if b {
+		w.WriteByte(1)
+	} else {
+		w.WriteByte(0)
+	}
+}
+
+// writeVarint writes x to w as a signed varint. Errors are reported by w.Flush.
+func writeVarint(w *bufio.Writer, x int64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], x)
+	w.Write(buf[:n])
+}
+
+// writeStrings writes the number of the strings followed by the strings.
+func writeStrings(w *bufio.Writer, strs []string) {
+	writeUvarint(w, uint64(len(strs)))
+	for _, s := range strs {
+		writeString(w, s)
+	}
+}
+
+// snapshotReader reads the values of a snapshot, keeping the first error, after which it returns zero values.
+type snapshotReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (s *snapshotReader) bool() bool {
+	if s.err != nil {
+		return false
+	}
+
+	b, err := s.r.ReadByte()
+	s.err = unexpectedEOF(err)
+	return b != 0
+}
+
+func (s *snapshotReader) int() int {
+	if s.err != nil {
+		return 0
+	}
+
+	x, err := binary.ReadVarint(s.r)
+	s.err = unexpectedEOF(err)
+	return int(x)
+}
+
+func (s *snapshotReader) uint() uint64 {
+	if s.err != nil {
+		return 0
+	}
+
+	x, err := binary.ReadUvarint(s.r)
+	s.err = unexpectedEOF(err)
+	return x
+}
+
+func (s *snapshotReader) string() string {
+	if s.err != nil {
+		return ""
+	}
+
+	str, err := readString(s.r)
+	s.err = err
+	return str
+}
+
+func (s *snapshotReader) strings() []string {
+	n := s.uint()
+	var strs []string
+	for i := uint64(0); i < n && s.err == nil; i++ {
+		strs = append(strs, s.string())
+	}
+
+	return strs
+}
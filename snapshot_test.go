@@ -0,0 +1,91 @@
+package rustemmer
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	lemmas, err := LoadLemmaDictionary(strings.NewReader("стали\tстать\nлюди\tчеловек\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := DefaultRules()
+	rules.Derivationals = append(rules.Derivationals, "чик")
+
+	testStemmers := []*RuStemmer{
+		New(),
+		New(WithAlgorithm(AlgorithmSnowball), WithOutputSeparator("|"), WithCache(10), WithMinWordLength(3)),
+		New(
+			WithRules(rules),
+			WithRussianStopWords(),
+			WithExceptions(map[string]string{"путь": "пут"}),
+			WithProtectedWords([]string{"Вагоны"}),
+			WithAbbreviationDetection(),
+			WithDictionary(BuildDictionary([]string{"вазы"})),
+			WithLemmaDictionary(lemmas),
+			WithPrefixStripping([]string{"пере"}),
+			WithNumberPolicy(NumberDrop),
+			WithAlphanumericPolicy(NumberSplit),
+			WithHyphenatedWords(true),
+			WithDiacriticStripping(),
+		),
+		New(WithTokenizerPattern("[а-яё]+"), WithStrength(StrengthLight)),
+		New(WithTokenizerPattern("[^ ]+"), WithInvalidWordReplacement("<unk>")),
+		New(WithAlgorithmVersion(1)),
+		New(WithIdempotentStems()),
+	}
+	text := "Вагоны стали, люди и заказчик перечитали путь; ООО «Интернет-магазины» работали 24/7 в 2024году, вазы…" +
+		" ценнейший академии"
+
+	for _, stemmer := range testStemmers {
+		data, err := stemmer.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		restored := New(WithCaseFolding())
+		if err := restored.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+
+		if expected, result := stemmer.NormalizeText(text), restored.NormalizeText(text); result != expected {
+			t.Errorf("Not equal: %s != %s", expected, result)
+		}
+		if again, err := restored.MarshalBinary(); err != nil || string(again) != string(data) {
+			t.Errorf("Expected the snapshot of a restored stemmer to be the same, got %v", err)
+		}
+	}
+}
+
+func TestMarshalBinaryErrors(t *testing.T) {
+	testOptions := []Option{
+		WithWordChars(isWordChar),
+		WithNonRussianStemmer(New()),
+		WithOutputTransform(strings.ToUpper),
+		WithStats(&StatsCollector{}),
+	}
+	for _, opt := range testOptions {
+		if _, err := New(opt).MarshalBinary(); !errors.Is(err, ErrNotSerializable) {
+			t.Errorf("Expected ErrNotSerializable, got %v", err)
+		}
+	}
+
+	data, err := New(WithRussianStopWords()).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := New()
+	if err := r.UnmarshalBinary(append([]byte{snapshotVersion + 1}, data[1:]...)); !errors.Is(err, ErrSnapshotVersion) {
+		t.Errorf("Expected ErrSnapshotVersion, got %v", err)
+	}
+	for _, n := range []int{0, 1, len(data) / 2, len(data) - 1} {
+		if err := r.UnmarshalBinary(data[:n]); err != io.ErrUnexpectedEOF {
+			t.Errorf("Expected io.ErrUnexpectedEOF for %d bytes, got %v", n, err)
+		}
+	}
+	if base := r.GetWordBase("вагоны"); base != "вагон" {
+		t.Errorf("Expected a failed UnmarshalBinary to keep the stemmer, got %s", base)
+	}
+}
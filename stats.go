@@ -0,0 +1,125 @@
+package rustemmer
+
+import (
+	"sync"
+)
+
+// StatsObserver is notified of the work of a stemmer configured with WithStats.
+// Implement it to export the statistics to a metrics system, such as counters and histograms of Prometheus.
+// A stemmer and its clones call it from their goroutines, so it must be safe for concurrent use.
+type StatsObserver interface {
+	// WordStemmed is called with the length in runes of the base of every word returned by GetWordBase,
+	// StemRunes and StemBytes or by the functions built on them, once per word even for compound words.
+	WordStemmed(stemLength int)
+	// StepApplied is called with one of the Step constants for every step removing a suffix from a word,
+	// or a prefix for StepPrefix. Words whose bases are found in the cache or a dictionary go through no steps.
+	StepApplied(step string)
+	// CacheLookup is called for every lookup of the cache enabled with WithCache, reporting whether it was a hit.
+	CacheLookup(hit bool)
+}
+
+// Stats describes the work of the stemmers reporting to a StatsCollector.
+type Stats struct {
+	// Words is the number of words stemmed.
+	Words uint64
+	// StemRunes is the total length in runes of the bases of the words.
+	StemRunes uint64
+	// CacheHits is the number of words whose base was found in the cache.
+	CacheHits uint64
+	// CacheMisses is the number of words whose base had to be computed despite the cache.
+	CacheMisses uint64
+	// Steps maps the names of the steps, the Step constants, to the number of times they removed a suffix.
+	Steps map[string]uint64
+}
+
+// AverageStemLength returns the average length of the bases in runes, or 0 if no words were stemmed.
+func (s Stats) AverageStemLength() float64 {
+	if s.Words == 0 {
+		return 0
+	}
+
+	return float64(s.StemRunes) / float64(s.Words)
+}
+
+// StatsCollector is a StatsObserver counting the words, steps and cache lookups in memory.
+// It is safe for concurrent use, so one collector may be shared by a stemmer, its clones and Pool.
+// The zero value is an empty collector ready to use.
+type StatsCollector struct {
+	mu    sync.Mutex
+	stats Stats
+}
+
+var _ StatsObserver = (*StatsCollector)(nil)
+
+// WithStats makes the stemmer report its work to the observer, such as a StatsCollector.
+// A nil observer disables the reporting.
+func WithStats(o StatsObserver) Option {
+	return func(r *RuStemmer) {
+		r.stats = o
+	}
+}
+
+// WordStemmed counts a word and the length of its base.
+func (c *StatsCollector) WordStemmed(stemLength int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Words++
+	c.stats.StemRunes += uint64(stemLength)
+}
+
+// StepApplied counts a step.
+func (c *StatsCollector) StepApplied(step string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stats.Steps == nil {
+		c.stats.Steps = map[string]uint64{}
+	}
+	c.stats.Steps[step]++
+}
+
+// CacheLookup counts a cache hit or miss.
+func (c *StatsCollector) CacheLookup(hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if hit {
+		c.stats.CacheHits++
+	} else {
+		c.stats.CacheMisses++
+	}
+}
+
+// Stats returns a copy of the statistics collected since the collector was created or reset.
+func (c *StatsCollector) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Steps = make(map[string]uint64, len(c.stats.Steps))
+	for step, n := range c.stats.Steps {
+		stats.Steps[step] = n
+	}
+
+	return stats
+}
+
+// Reset sets the statistics to zero.
+func (c *StatsCollector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = Stats{}
+}
+
+// recordStep remembers the step applied to the word being stemmed, to report it once the steps are final.
+func (r *RuStemmer) recordStep(step string) {
+	if r.stats != nil {
+		r.steps = append(r.steps, step)
+	}
+}
+
+// reportSteps reports the steps applied to the word being stemmed and forgets them.
+func (r *RuStemmer) reportSteps() {
+	for _, step := range r.steps {
+		r.stats.StepApplied(step)
+	}
+	r.steps = r.steps[:0]
+}
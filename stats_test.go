@@ -0,0 +1,74 @@
+package rustemmer
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestWithStats(t *testing.T) {
+	collector := &StatsCollector{}
+	stemmer := New(WithStats(collector), WithCache(10))
+	stemmer.NormalizeText("вагоны стоят, вагоны едут, ая")
+
+	stats := collector.Stats()
+	expected := Stats{
+		Words:       5,
+		StemRunes:   19,
+		CacheHits:   1,
+		CacheMisses: 4,
+		Steps:       map[string]uint64{StepNoun: 1, StepVerb: 1},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("Not equal: %+v != %+v", expected, stats)
+	}
+	if average := stats.AverageStemLength(); average != 3.8 {
+		t.Errorf("Not equal: 3.8 != %v", average)
+	}
+
+	collector.Reset()
+	if stats := collector.Stats(); !reflect.DeepEqual(stats, Stats{Steps: map[string]uint64{}}) || stats.AverageStemLength() != 0 {
+		t.Errorf("Expected zero statistics, got %+v", stats)
+	}
+}
+
+func TestWithStatsConcurrent(t *testing.T) {
+	collector := &StatsCollector{}
+	stemmer := New(WithStats(collector))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(r *RuStemmer) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r.GetWordBase("вагонами")
+			}
+		}(stemmer.Clone())
+	}
+	wg.Wait()
+
+	if stats := collector.Stats(); stats.Words != 400 || stats.Steps[StepNoun] != 400 {
+		t.Errorf("Not equal: 400 400 != %d %d", stats.Words, stats.Steps[StepNoun])
+	}
+}
+
+func TestWithStatsWordOnce(t *testing.T) {
+	// Every word is reported once, however its base is found.
+	collector := &StatsCollector{}
+	stemmer := New(WithStats(collector), WithHyphenatedWords(true))
+	stemmer.GetWordBase("интернет-магазины")
+	stemmer.GetCompoundBase("красно-белые")
+	stemmer.StemRunes([]rune("вагонами"))
+	stemmer.StemBytes([]byte("вагоны"))
+
+	stats := collector.Stats()
+	expected := Stats{
+		Words:     4,
+		StemRunes: 35,
+		Steps:     map[string]uint64{StepNoun: 4, StepAdjectival: 1},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("Not equal: %+v != %+v", expected, stats)
+	}
+}
@@ -0,0 +1,71 @@
+package rustemmer
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// russianStopWords is the list of Russian stop words of the Snowball project,
+// followed by the spellings of its words with "ё".
+var russianStopWords = []string{
+	"и", "в", "во", "не", "что", "он", "на", "я", "с", "со", "как", "а", "то", "все", "она", "так", "его",
+	"но", "да", "ты", "к", "у", "же", "вы", "за", "бы", "по", "только", "ее", "мне", "было", "вот", "от",
+	"меня", "еще", "нет", "о", "из", "ему", "теперь", "когда", "даже", "ну", "вдруг", "ли", "если", "уже",
+	"или", "ни", "быть", "был", "него", "до", "вас", "нибудь", "опять", "уж", "вам", "ведь", "там", "потом",
+	"себя", "ничего", "ей", "может", "они", "тут", "где", "есть", "надо", "ней", "для", "мы", "тебя", "их",
+	"чем", "была", "сам", "чтоб", "без", "будто", "чего", "раз", "тоже", "себе", "под", "будет", "ж", "тогда",
+	"кто", "этот", "того", "потому", "этого", "какой", "совсем", "ним", "здесь", "этом", "один", "почти",
+	"мой", "тем", "чтобы", "нее", "сейчас", "были", "куда", "зачем", "всех", "никогда", "можно", "при",
+	"наконец", "два", "об", "другой", "хоть", "после", "над", "больше", "тот", "через", "эти", "нас", "про",
+	"всего", "них", "какая", "много", "разве", "три", "эту", "моя", "впрочем", "хорошо", "свою", "этой",
+	"перед", "иногда", "лучше", "чуть", "том", "нельзя", "такой", "им", "более", "всегда", "конечно", "всю",
+	"между",
+	"её", "ещё", "неё",
+}
+
+// russianStopWordSet is the set of the built-in Russian stop words.
+var russianStopWordSet = stopWordSet(russianStopWords)
+
+// RussianStopWords returns a copy of the built-in list of Russian stop words,
+// which can be extended and passed to WithStopWords.
+func RussianStopWords() []string {
+	return append([]string{}, russianStopWords...)
+}
+
+// LoadStopWords returns the stop words read from r, separated by white space, such as one word per line.
+// The words can be passed to WithStopWords or SetStopWords.
+func LoadStopWords(r io.Reader) ([]string, error) {
+	words := []string{}
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		words = append(words, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return words, nil
+}
+
+// WithRussianStopWords skips the built-in Russian stop words, as WithStopWords(RussianStopWords()) does.
+func WithRussianStopWords() Option {
+	return WithStopWords(russianStopWords)
+}
+
+// SetStopWords replaces the stop words of this stemmer, as WithStopWords does.
+// Without words no word is skipped.
+func (r *RuStemmer) SetStopWords(words []string) {
+	r.stopWords = stopWordSet(words)
+}
+
+// stopWordSet returns the set of the lowercased words.
+func stopWordSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[strings.ToLower(word)] = true
+	}
+
+	return set
+}
@@ -0,0 +1,50 @@
+package rustemmer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithRussianStopWords(t *testing.T) {
+	text := "И вот на улице её ждали вагоны, а в них были важные новости"
+	stemmer := New(WithRussianStopWords())
+	if normalized := stemmer.NormalizeText(text); normalized != "улиц ждал вагон важн новост" {
+		t.Errorf("Not equal: %s != %s", "улиц ждал вагон важн новост", normalized)
+	}
+
+	words := RussianStopWords()
+	words[0] = "вагоны"
+	if normalized := stemmer.NormalizeText("вагоны и"); normalized != "вагон" {
+		t.Errorf("Not equal: %s != %s", "вагон", normalized)
+	}
+
+	stemmer = New(WithStopWords(append(RussianStopWords(), "улице")))
+	if normalized := stemmer.NormalizeText(text); normalized != "ждал вагон важн новост" {
+		t.Errorf("Not equal: %s != %s", "ждал вагон важн новост", normalized)
+	}
+}
+
+func TestSetStopWords(t *testing.T) {
+	stemmer := New()
+	stemmer.SetStopWords([]string{"В", "вагоне"})
+	if normalized := stemmer.NormalizeText("В вагоне метро"); normalized != "метр" {
+		t.Errorf("Not equal: %s != %s", "метр", normalized)
+	}
+
+	stemmer.SetStopWords(nil)
+	if normalized := stemmer.NormalizeText("В вагоне метро"); normalized != "В вагон метр" {
+		t.Errorf("Not equal: %s != %s", "В вагон метр", normalized)
+	}
+}
+
+func TestLoadStopWords(t *testing.T) {
+	words, err := LoadStopWords(strings.NewReader("вот\nещё  и\n\nвагоны\r\n"))
+	if expected := []string{"вот", "ещё", "и", "вагоны"}; err != nil || !reflect.DeepEqual(words, expected) {
+		t.Errorf("Not equal: %v != %v %v", expected, words, err)
+	}
+
+	if words, err := LoadStopWords(strings.NewReader("")); err != nil || len(words) != 0 {
+		t.Errorf("Expected no words, got %v %v", words, err)
+	}
+}
@@ -0,0 +1,100 @@
+package rustemmer
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+// Normalize reads text from src and writes it to dst normalized as by NormalizeText.
+func Normalize(dst io.Writer, src io.Reader) error {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.Normalize(dst, src)
+}
+
+// Normalize reads text from src and writes it to dst normalized as by NormalizeText, without keeping
+// the whole text in memory. The text is processed in parts split at whitespace, so a tokenizer set
+// with WithTokenizer must not find words spanning whitespace. Parts of the text without whitespace
+// must not exceed bufio.MaxScanTokenSize bytes, otherwise bufio.ErrTooLong is returned.
+func (r *RuStemmer) Normalize(dst io.Writer, src io.Reader) error {
+	scanner := bufio.NewScanner(src)
+	scanner.Split(bufio.ScanWords)
+	buf := bufio.NewWriter(dst)
+
+	first := true
+	var err error
+	for err == nil && scanner.Scan() {
+		r.ForEachStem(scanner.Text(), func(stem string) bool {
+			if !first {
+				buf.WriteString(r.separator)
+			}
+			first = false
+			_, err = buf.WriteString(stem)
+			return err == nil
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return buf.Flush()
+}
+
+// ScanRussianWords is a bufio.SplitFunc returning the words of the text as found by the default tokenizer:
+// runs of letters, combining marks, ASCII digits and "_", joined by apostrophes followed by letters.
+// Everything else, including invalid UTF-8 bytes, separates words. With it a bufio.Scanner yields one word
+// at a time, so the words of a text of any size can be stemmed in constant memory:
+//
+//	scanner := bufio.NewScanner(src)
+//	scanner.Split(rustemmer.ScanRussianWords)
+//	for scanner.Scan() {
+//		base := rustemmer.GetWordBase(scanner.Text())
+//		...
+//	}
+func ScanRussianWords(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	start := 0
+	for start < len(data) {
+		if !atEOF && !utf8.FullRune(data[start:]) {
+			return start, nil, nil
+		}
+		char, size := utf8.DecodeRune(data[start:])
+		if isWordChar(char) {
+			break
+		}
+		start += size
+	}
+
+	lettersOnly := false
+	for i := start; i < len(data); {
+		if !atEOF && !utf8.FullRune(data[i:]) {
+			return start, nil, nil
+		}
+		char, size := utf8.DecodeRune(data[i:])
+		if isApostrophe(char) {
+			if !atEOF && !utf8.FullRune(data[i + size:]) {
+				return start, nil, nil
+			}
+			if next, _ := utf8.DecodeRune(data[i + size:]); isLetterOrMark(next) {
+				lettersOnly = true
+				i += size
+				continue
+			}
+		}
+		if !isWordChar(char) {
+			return i + size, data[start:i], nil
+		}
+		if lettersOnly && !isLetterOrMark(char) {
+			return i, data[start:i], nil
+		}
+		i += size
+	}
+	if atEOF && len(data) > start {
+		return len(data), data[start:], nil
+	}
+
+	return start, nil, nil
+}
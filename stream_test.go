@@ -0,0 +1,88 @@
+package rustemmer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNormalize(t *testing.T) {
+	testTexts := []string{
+		"Результаты проверки города в DB: \"Санкт-Петербурга\" не нашлось!",
+		"  Важная новость (!)\n\nВ вагоне\tметро заклинило вал  ",
+		"",
+		" \n ",
+		strings.Repeat("Глава СКР: спортсменам могли умышленно подбросить мельдоний. ", 10000),
+	}
+
+	for _, text := range testTexts {
+		var buf bytes.Buffer
+		if err := Normalize(&buf, strings.NewReader(text)); err != nil {
+			t.Fatal(err)
+		}
+		if expected := NormalizeText(text); buf.String() != expected {
+			t.Errorf("Not equal: %.50q != %.50q", expected, buf.String())
+		}
+	}
+
+	stemmer := New(WithOutputSeparator("\n"), WithStopWords([]string{"в"}))
+	var buf bytes.Buffer
+	if err := stemmer.Normalize(&buf, strings.NewReader("Важная новость: в вагоне")); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "Важн\nновост\nвагон" {
+		t.Errorf("Not equal: %q != %q", "Важн\nновост\nвагон", buf.String())
+	}
+}
+
+// failingWriter is an io.Writer for tests that always fails.
+type failingWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestNormalizeErrors(t *testing.T) {
+	text := strings.Repeat("вагоны ", 10000)
+	if err := Normalize(failingWriter{}, strings.NewReader(text)); err != errWrite {
+		t.Errorf("Expected errWrite, got %v", err)
+	}
+	if err := Normalize(&bytes.Buffer{}, strings.NewReader(strings.Repeat("в", 100000))); err == nil {
+		t.Error("Expected an error for a too long word")
+	}
+}
+
+func TestScanRussianWords(t *testing.T) {
+	testTexts := []string{
+		"Результаты проверки города в DB: \"Санкт-Петербурга\" не нашлось!",
+		"  Важная новость (!)\n\nВ вагоне\tметро заклинило вал  ",
+		"г. Москва, ул. Полярная, д. 31А, стр. 1",
+		"ёлки\xffпалки \xd0",
+		"О’Нил и д'Артаньян: д'1 д'Арт1 'вагоны' стоят’",
+		"",
+		" \n ",
+	}
+
+	stemmer := New()
+	for _, text := range testTexts {
+		expected := stemmer.splitWords(text)
+		for _, src := range []io.Reader{strings.NewReader(text), iotest.OneByteReader(strings.NewReader(text))} {
+			scanner := bufio.NewScanner(src)
+			scanner.Split(ScanRussianWords)
+			words := []string{}
+			for scanner.Scan() {
+				words = append(words, scanner.Text())
+			}
+			if err := scanner.Err(); err != nil || !reflect.DeepEqual(words, expected) {
+				t.Errorf("Not equal: %q != %q %v", expected, words, err)
+			}
+		}
+	}
+}
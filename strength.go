@@ -0,0 +1,54 @@
+package rustemmer
+
+import (
+	"github.com/liderman/rustemmer/internal/porter"
+)
+
+// Strength selects how much of a word the stemmer removes, trading precision for recall.
+type Strength int
+
+const (
+	// StrengthStandard removes the suffixes of the Porter algorithm. It is the default.
+	StrengthStandard Strength = iota
+	// StrengthLight removes only the noun and adjective endings at step 1, keeping the endings of verbs,
+	// gerunds and participles and the superlative and derivational suffixes, so fewer distinct words share a base.
+	// The reflexive endings are still removed, so "ся" does not leave "с" behind, as are "и", "нн" and "ь"
+	// at steps 2 and 4.
+	StrengthLight
+	// StrengthAggressive also removes the derivational suffixes "ик", "ец", "ниц", "чик", "щик", "тель" and "изм"
+	// in R2, besides "ост" and "ость", so "учительница" and "учитель" share the base "учител".
+	StrengthAggressive
+)
+
+// aggressiveDerivational is the table of the derivational suffixes removed with StrengthAggressive.
+var aggressiveDerivational = mergeSuffixes(suffixDerivational, []string{"ик", "ец", "ниц", "чик", "щик", "тель", "изм"})
+
+var trieEmpty = porter.NewTrie(nil)
+var trieAggressiveDerivational = porter.NewTrie(aggressiveDerivational)
+
+// WithStrength sets the suffix tables of the strength. Options given after it that change the suffix tables,
+// such as WithRules, override the corresponding tables.
+func WithStrength(strength Strength) Option {
+	return func(r *RuStemmer) {
+		r.suffixPerfectiveGerunds = triePerfectiveGerunds
+		r.suffixReflexives = trieReflexives
+		r.suffixAdjective = trieAdjective
+		r.suffixParticiple = trieParticiple
+		r.suffixVerb = trieVerb
+		r.suffixNoun = trieNoun
+		r.suffixSuperlative = trieSuperlative
+		r.suffixDerivational = trieDerivational
+
+		switch strength {
+		case StrengthLight:
+			r.suffixPerfectiveGerunds = [2]*porter.Trie{trieEmpty, trieEmpty}
+			r.suffixParticiple = [2]*porter.Trie{trieEmpty, trieEmpty}
+			r.suffixVerb = [2]*porter.Trie{trieEmpty, trieEmpty}
+			r.suffixSuperlative = trieEmpty
+			r.suffixDerivational = trieEmpty
+		case StrengthAggressive:
+			r.suffixDerivational = trieAggressiveDerivational
+		}
+		r.resetCache()
+	}
+}
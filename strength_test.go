@@ -0,0 +1,43 @@
+package rustemmer
+
+import (
+	"testing"
+)
+
+func TestWithStrength(t *testing.T) {
+	testWords := map[string][3]string{
+		// Standard, light and aggressive bases.
+		"вагонами"     : {"вагон", "вагон", "вагон"},
+		"важная"       : {"важн", "важн", "важн"},
+		"читающего"    : {"чита", "читающ", "чита"},
+		"прочитавшись" : {"прочита", "прочитавш", "прочита"},
+		"читали"       : {"чита", "читал", "чита"},
+		"умывался"     : {"умыва", "умывал", "умыва"},
+		"важнейший"    : {"важн", "важнейш", "важн"},
+		"бдительность" : {"бдительн", "бдительност", "бдительн"},
+		"учительница"  : {"учительниц", "учительниц", "учител"},
+		"учитель"      : {"учител", "учител", "учител"},
+		"заказчик"     : {"заказчик", "заказчик", "заказ"},
+	}
+
+	stemmers := []*RuStemmer{
+		New(WithStrength(StrengthStandard)),
+		New(WithStrength(StrengthLight)),
+		New(WithStrength(StrengthAggressive)),
+	}
+	for word, bases := range testWords {
+		if base := New().GetWordBase(word); base != bases[0] {
+			t.Errorf("Not equal: [%s default] %s != %s", word, bases[0], base)
+		}
+		for k, stemmer := range stemmers {
+			if base := stemmer.GetWordBase(word); base != bases[k] {
+				t.Errorf("Not equal: [%s %d] %s != %s", word, k, bases[k], base)
+			}
+		}
+	}
+
+	// A later strength replaces an earlier one.
+	if base := New(WithStrength(StrengthLight), WithStrength(StrengthStandard)).GetWordBase("читали"); base != "чита" {
+		t.Errorf("Not equal: чита != %s", base)
+	}
+}
@@ -0,0 +1,110 @@
+package rustemmer
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidUTF8 is returned by GetWordBaseStrict for words that are not valid UTF-8.
+var ErrInvalidUTF8 = errors.New("rustemmer: invalid UTF-8")
+
+// ErrEmptyWord is returned by StemStrict for words that are empty or consist of whitespace.
+var ErrEmptyWord = errors.New("rustemmer: empty word")
+
+// ErrNonCyrillic is returned by StemStrict for words without Cyrillic letters, such as "Windows" or "!!!".
+var ErrNonCyrillic = errors.New("rustemmer: word without Cyrillic letters")
+
+// ErrNoVowels is returned by StemStrict for words without Russian vowels, such as "ррр" or "ВКП",
+// which the algorithm returns unchanged.
+var ErrNoVowels = errors.New("rustemmer: word without vowels")
+
+// InvalidWordPolicy selects what happens to the words of a text that StemStrict rejects.
+type InvalidWordPolicy int
+
+const (
+	// InvalidWordStem stems the words like any other words, which is the default.
+	InvalidWordStem InvalidWordPolicy = iota
+	// InvalidWordKeep passes the words through verbatim.
+	InvalidWordKeep
+	// InvalidWordSkip leaves the words out when a text is split into words, as stop words are.
+	InvalidWordSkip
+	// InvalidWordReplace replaces the words with the replacement set by WithInvalidWordReplacement.
+	InvalidWordReplace
+)
+
+// WithInvalidWordPolicy sets what happens to the words StemStrict rejects, such as "ррр" or words
+// without Cyrillic letters found by a custom tokenizer. Numbers follow WithNumberPolicy instead.
+func WithInvalidWordPolicy(policy InvalidWordPolicy) Option {
+	return func(r *RuStemmer) {
+		r.invalidWordPolicy = policy
+	}
+}
+
+// WithInvalidWordReplacement makes the stemmer return the replacement, such as "<unk>", for the words
+// StemStrict rejects, as InvalidWordReplace does.
+func WithInvalidWordReplacement(replacement string) Option {
+	return func(r *RuStemmer) {
+		r.invalidWordPolicy = InvalidWordReplace
+		r.invalidReplacement = replacement
+	}
+}
+
+// GetWordBaseStrict returns the base word, or ErrInvalidUTF8 if the word is not valid UTF-8.
+func GetWordBaseStrict(word string) (string, error) {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.GetWordBaseStrict(word)
+}
+
+// GetWordBaseStrict returns the base word, or ErrInvalidUTF8 if the word is not valid UTF-8.
+// GetWordBase, by contrast, keeps invalid UTF-8 sequences as they are and stems only the valid
+// Cyrillic part following the last of them.
+func (r *RuStemmer) GetWordBaseStrict(word string) (string, error) {
+	if !utf8.ValidString(word) {
+		return "", ErrInvalidUTF8
+	}
+	return r.GetWordBase(word), nil
+}
+
+// StemStrict returns the base word, or an error for a word the algorithm cannot stem.
+func StemStrict(word string) (string, error) {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemStrict(word)
+}
+
+// StemStrict returns the base word, or an error for a word the algorithm cannot stem: ErrEmptyWord,
+// ErrInvalidUTF8, ErrNonCyrillic or ErrNoVowels, checked in this order. GetWordBase, by contrast,
+// returns such words unchanged. Words without Cyrillic letters are accepted if the stemmer has
+// a stemmer for them, set with WithNonRussianStemmer.
+func (r *RuStemmer) StemStrict(word string) (string, error) {
+	if err := r.checkWord(word); err != nil {
+		return "", err
+	}
+	return r.GetWordBase(word), nil
+}
+
+// checkWord returns the error StemStrict reports for the word, or nil if it can be stemmed.
+func (r *RuStemmer) checkWord(word string) error {
+	switch {
+	case strings.TrimSpace(word) == "":
+		return ErrEmptyWord
+	case !utf8.ValidString(word):
+		return ErrInvalidUTF8
+	case !IsRussianWord(word):
+		if r.nonRussianStemmer != nil {
+			return nil
+		}
+		return ErrNonCyrillic
+	case !strings.ContainsAny(toLower(word), VOWEL):
+		return ErrNoVowels
+	}
+
+	return nil
+}
+
+// isInvalidWord reports whether the policy set with WithInvalidWordPolicy applies to the word.
+func (r *RuStemmer) isInvalidWord(word string) bool {
+	return !isNumber(word) && r.checkWord(word) != nil
+}
@@ -0,0 +1,126 @@
+package rustemmer
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGetWordBaseStrict(t *testing.T) {
+	if base, err := GetWordBaseStrict("вазы"); err != nil || base != "ваз" {
+		t.Errorf("Expected ваз, got %q, %v", base, err)
+	}
+
+	invalidWords := []string{
+		"ваз\xd1",
+		"\x80вазы",
+		"ва\xd0\xd0зы",
+	}
+	for _, word := range invalidWords {
+		if _, err := GetWordBaseStrict(word); err != ErrInvalidUTF8 {
+			t.Errorf("Expected ErrInvalidUTF8 for %q, got %v", word, err)
+		}
+	}
+}
+
+func TestGetWordBaseInvalidUTF8(t *testing.T) {
+	testWords := map[string]string{
+		"ваз\xd1"          : "ваз\xd1",
+		"\x80вазы"         : "\x80ваз",
+		"ва\xd0зы"         : "ва\xd0зы",
+		"вагоны\xffвагоны" : "вагоны\xffвагон",
+	}
+
+	stemmers := []*RuStemmer{New(), New(WithCaseFolding()), New(WithUnicodeNormalization(true))}
+	for _, stemmer := range stemmers {
+		for word, base := range testWords {
+			if testBase := stemmer.GetWordBase(word); testBase != base {
+				t.Errorf("Not equal: [%q] %q != %q", word, base, testBase)
+			}
+		}
+	}
+
+	if base := New(WithCaseFolding()).GetWordBase("ВАЗЫ\xd1бои\u0306"); base != "вазы\xd1бо" {
+		t.Errorf("Not equal: %q != %q", "вазы\xd1бо", base)
+	}
+}
+
+func TestNormalizeTextInvalidUTF8(t *testing.T) {
+	text := "Важная \xd0новость\x80 в вагоне\xff"
+
+	if result := NormalizeText(text); result != "Важн новост в вагон" {
+		t.Errorf("Not equal: %q != %q", "Важн новост в вагон", result)
+	}
+	if result := NormalizeTextPreserve(text); result != "Важн \xd0новост\x80 в вагон\xff" {
+		t.Errorf("Not equal: %q != %q", "Важн \xd0новост\x80 в вагон\xff", result)
+	}
+	for _, token := range Tokenize(text) {
+		if strings.ContainsRune(token.Stem, utf8.RuneError) {
+			t.Errorf("Unexpected replacement character in %q", token.Stem)
+		}
+	}
+}
+
+func TestStemStrict(t *testing.T) {
+	testWords := map[string]error{
+		"вазы"      : nil,
+		"31А"       : nil,
+		""          : ErrEmptyWord,
+		" \t"       : ErrEmptyWord,
+		"ваз\xd1"   : ErrInvalidUTF8,
+		"Windows"   : ErrNonCyrillic,
+		"2024"      : ErrNonCyrillic,
+		"!!!"       : ErrNonCyrillic,
+		"ррр"       : ErrNoVowels,
+		"ВКП"       : ErrNoVowels,
+	}
+
+	for word, expected := range testWords {
+		base, err := StemStrict(word)
+		if err != expected {
+			t.Errorf("Expected %v for %q, got %v", expected, word, err)
+		}
+		if err == nil && base != GetWordBase(word) {
+			t.Errorf("Not equal: %s != %s", GetWordBase(word), base)
+		}
+		if err != nil && base != "" {
+			t.Errorf("Expected no base for %q, got %q", word, base)
+		}
+	}
+
+	stemmer := New(WithNonRussianStemmer(New()))
+	if _, err := stemmer.StemStrict("Windows"); err != nil {
+		t.Errorf("Expected no error with a non-Russian stemmer, got %v", err)
+	}
+}
+
+func TestWithInvalidWordPolicy(t *testing.T) {
+	punctuation := WithTokenizerPattern("[^ ]+")
+	text := "Вагоны ррр стоят !!! Windows 2024"
+
+	testStemmers := map[string]*RuStemmer{
+		"Вагон ррр сто !!! Windows 2024"     : New(punctuation),
+		"вагон ррр сто !!! Windows 2024"     : New(punctuation, WithCaseFolding(), WithInvalidWordPolicy(InvalidWordKeep)),
+		"Вагон сто 2024"                     : New(punctuation, WithInvalidWordPolicy(InvalidWordSkip)),
+		"Вагон <unk> сто <unk> <unk> 2024"   : New(punctuation, WithInvalidWordReplacement("<unk>")),
+		"Вагон  сто   2024"                  : New(punctuation, WithInvalidWordPolicy(InvalidWordReplace)),
+		"Вагон <unk> сто <unk> <unk>"        : New(punctuation, WithInvalidWordReplacement("<unk>"), WithNumberPolicy(NumberDrop)),
+	}
+
+	for expected, stemmer := range testStemmers {
+		if normalized := stemmer.NormalizeText(text); normalized != expected {
+			t.Errorf("Not equal: %s != %s", expected, normalized)
+		}
+		if normalized := string(stemmer.AppendNormalized(nil, text)); normalized != expected {
+			t.Errorf("Not equal: %s != %s", expected, normalized)
+		}
+	}
+
+	stemmer := New(WithInvalidWordReplacement("?"))
+	if base := stemmer.GetWordBase(""); base != "?" {
+		t.Errorf("Not equal: ? != %s", base)
+	}
+	if base := stemmer.GetWordBase("вазы"); base != "ваз" {
+		t.Errorf("Not equal: ваз != %s", base)
+	}
+}
@@ -0,0 +1,134 @@
+package rustemmer
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/liderman/rustemmer/internal/porter"
+)
+
+// SuffixTable names a table of suffixes removed by the Porter steps.
+type SuffixTable int
+
+const (
+	// SuffixPerfectiveGerund is the table of perfective gerund endings, such as "вшись".
+	SuffixPerfectiveGerund SuffixTable = iota
+	// SuffixReflexive is the table of reflexive endings, such as "ся".
+	SuffixReflexive
+	// SuffixAdjective is the table of adjective endings, such as "ого".
+	SuffixAdjective
+	// SuffixParticiple is the table of participle endings, which are adjective endings
+	// preceded by a participle suffix, such as "ющего".
+	SuffixParticiple
+	// SuffixVerb is the table of verb endings, such as "ила".
+	SuffixVerb
+	// SuffixNoun is the table of noun endings, such as "ями".
+	SuffixNoun
+	// SuffixSuperlative is the table of superlative suffixes, such as "ейш".
+	SuffixSuperlative
+	// SuffixDerivational is the table of derivational suffixes, such as "ость".
+	SuffixDerivational
+)
+
+// WithExtraNounSuffixes registers additional noun endings, as AddNounSuffixes does.
+func WithExtraNounSuffixes(suffixes []string) Option {
+	return func(r *RuStemmer) {
+		r.AddNounSuffixes(suffixes...)
+	}
+}
+
+// WithExtraVerbSuffixes registers additional verb endings in the group 1 or 2 of the Porter algorithm.
+// The endings of the group 1, such as "ла", are removed only after "а" or "я".
+// It panics if the group is neither 1 nor 2.
+func WithExtraVerbSuffixes(group int, suffixes []string) Option {
+	if group != 1 && group != 2 {
+		panic(fmt.Sprintf("rustemmer: invalid verb suffix group %d", group))
+	}
+
+	return func(r *RuStemmer) {
+		r.suffixVerb[group - 1] = porter.NewTrie(mergeSuffixes(r.suffixVerb[group - 1].Suffixes(), suffixes))
+		r.resetCache()
+	}
+}
+
+// WithReplaceSuffixTable replaces the built-in table of suffixes for this stemmer.
+// The perfective gerund, participle and verb endings are divided into two groups, the first of which
+// is only removed after "а" or "я"; for these tables the suffixes replace both groups and are removed
+// after any letter. The participle suffixes are complete endings, such as "ющего"; replacing
+// the adjective endings also rebuilds the participle endings from them, as AddAdjectiveSuffixes does.
+// The package-level tables are not modified, so other stemmers are unaffected.
+func WithReplaceSuffixTable(table SuffixTable, suffixes []string) Option {
+	return func(r *RuStemmer) {
+		sorted := mergeSuffixes(nil, suffixes)
+		trie := porter.NewTrie(sorted)
+		groups := [2]*porter.Trie{porter.NewTrie(nil), trie}
+		switch table {
+		case SuffixPerfectiveGerund:
+			r.suffixPerfectiveGerunds = groups
+		case SuffixReflexive:
+			r.suffixReflexives = trie
+		case SuffixAdjective:
+			r.suffixAdjective = trie
+			r.suffixParticiple = newSuffixTries(participleSuffixes(sorted))
+		case SuffixParticiple:
+			r.suffixParticiple = groups
+		case SuffixVerb:
+			r.suffixVerb = groups
+		case SuffixNoun:
+			r.suffixNoun = trie
+		case SuffixSuperlative:
+			r.suffixSuperlative = trie
+		case SuffixDerivational:
+			r.suffixDerivational = trie
+		}
+		r.resetCache()
+	}
+}
+
+// AddNounSuffixes registers additional noun endings removed by this stemmer.
+// The package-level tables are not modified, so other stemmers are unaffected.
+func (r *RuStemmer) AddNounSuffixes(suffixes ...string) {
+	r.suffixNoun = porter.NewTrie(mergeSuffixes(r.suffixNoun.Suffixes(), suffixes))
+	r.resetCache()
+}
+
+// AddAdjectiveSuffixes registers additional adjective endings removed by this stemmer.
+// The endings are also combined with the participle suffixes, as the built-in ones are.
+// The package-level tables are not modified, so other stemmers are unaffected.
+func (r *RuStemmer) AddAdjectiveSuffixes(suffixes ...string) {
+	adjective := mergeSuffixes(r.suffixAdjective.Suffixes(), suffixes)
+	r.suffixAdjective = porter.NewTrie(adjective)
+	r.suffixParticiple = newSuffixTries(participleSuffixes(adjective))
+	r.resetCache()
+}
+
+// participleSuffixes returns the groups of participle endings built from the adjective endings.
+func participleSuffixes(adjective []string) [][]string {
+	return [][]string{
+		mergeSuffixes(nil, appendPrefix(adjective, participleMarkers[0])),
+		mergeSuffixes(nil, appendPrefix(adjective, participleMarkers[1])),
+	}
+}
+
+// mergeSuffixes returns a new table holding the suffixes of base followed by the new ones,
+// ordered from the longest to the shortest so that the longest matching suffix is removed.
+func mergeSuffixes(base []string, suffixes []string) []string {
+	seen := make(map[string]bool, len(base) + len(suffixes))
+	ret := make([]string, 0, len(base) + len(suffixes))
+	for _, suffixes := range [][]string{base, suffixes} {
+		for _, suffix := range suffixes {
+			if suffix == "" || seen[suffix] {
+				continue
+			}
+			seen[suffix] = true
+			ret = append(ret, suffix)
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return utf8.RuneCountInString(ret[i]) > utf8.RuneCountInString(ret[j])
+	})
+
+	return ret
+}
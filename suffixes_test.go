@@ -0,0 +1,190 @@
+package rustemmer
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/liderman/rustemmer/internal/porter"
+)
+
+func TestAddNounSuffixes(t *testing.T) {
+	stemmer := New()
+	stemmer.AddNounSuffixes("ация", "ациями")
+
+	testWords := map[string]string{
+		"организация"   : "организ",
+		"организациями" : "организ",
+		"вагонов"       : "вагон",
+		"вазы"          : "ваз",
+	}
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+
+	if base := New().GetWordBase("организация"); base != "организац" {
+		t.Errorf("Not equal: организац != %s", base)
+	}
+}
+
+func TestAddAdjectiveSuffixes(t *testing.T) {
+	stemmer := New()
+	stemmer.AddAdjectiveSuffixes("ейского")
+
+	testWords := map[string]string{
+		"европейского" : "европ",
+		"важного"      : "важн",
+	}
+	for word, base := range testWords {
+		if testBase := stemmer.GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+
+	if base := GetWordBase("европейского"); base != "европейск" {
+		t.Errorf("Not equal: европейск != %s", base)
+	}
+}
+
+func TestAddSuffixesResetsCache(t *testing.T) {
+	stemmer := New(WithCache(10))
+	if base := stemmer.GetWordBase("организация"); base != "организац" {
+		t.Errorf("Not equal: организац != %s", base)
+	}
+
+	stemmer.AddNounSuffixes("ация")
+	if base := stemmer.GetWordBase("организация"); base != "организ" {
+		t.Errorf("Not equal: организ != %s", base)
+	}
+}
+
+func TestSuffixTablesOrder(t *testing.T) {
+	tables := [][]string{
+		suffixPerfectiveGerunds[0], suffixPerfectiveGerunds[1], suffixAdjective, suffixVerb[0], suffixVerb[1],
+		suffixNoun, suffixSuperlative, suffixDerivational, suffixParticiple[0], suffixParticiple[1],
+	}
+
+	for _, table := range tables {
+		for k := 1; k < len(table); k++ {
+			if utf8.RuneCountInString(table[k - 1]) < utf8.RuneCountInString(table[k]) {
+				t.Errorf("Suffix %s is listed before the longer %s", table[k - 1], table[k])
+			}
+		}
+	}
+}
+
+func TestWithExtraSuffixes(t *testing.T) {
+	testOptions := []struct {
+		opts     []Option
+		word     string
+		expected string
+	}{
+		{nil, "организация", "организац"},
+		{[]Option{WithExtraNounSuffixes([]string{"ация"})}, "организация", "организ"},
+		{nil, "вывезти", "вывезт"},
+		{[]Option{WithExtraVerbSuffixes(2, []string{"ти"})}, "вывезти", "вывез"},
+		{nil, "делаху", "делах"},
+		{[]Option{WithExtraVerbSuffixes(1, []string{"ху"})}, "делаху", "дела"},
+		{[]Option{WithExtraVerbSuffixes(1, []string{"ху"})}, "пиху", "пих"},
+	}
+
+	for _, test := range testOptions {
+		if base := New(test.opts...).GetWordBase(test.word); base != test.expected {
+			t.Errorf("Not equal: [%s] %s != %s", test.word, test.expected, base)
+		}
+	}
+}
+
+func TestRemoveEndingsLongestGroup(t *testing.T) {
+	// The longest suffix of both groups is removed, whichever group it belongs to,
+	// and the suffixes of the first group are still removed only after "а" or "я".
+	testOptions := []struct {
+		opts     []Option
+		word     string
+		expected string
+	}{
+		{nil, "подписывал", "подписыва"},
+		{[]Option{WithExtraVerbSuffixes(2, []string{"ывал"})}, "подписывал", "подпис"},
+		{[]Option{WithExtraVerbSuffixes(1, []string{"вал"})}, "подписывал", "подписыва"},
+		{[]Option{WithExtraVerbSuffixes(1, []string{"вал"})}, "надавал", "нада"},
+		{[]Option{WithExtraVerbSuffixes(2, []string{"ывал"})}, "читала", "чита"},
+	}
+
+	for _, test := range testOptions {
+		if base := New(test.opts...).GetWordBase(test.word); base != test.expected {
+			t.Errorf("Not equal: [%s] %s != %s", test.word, test.expected, base)
+		}
+	}
+}
+
+func TestWithExtraVerbSuffixesInvalidGroup(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for group 3")
+		}
+	}()
+	WithExtraVerbSuffixes(3, []string{"ху"})
+}
+
+func TestWithReplaceSuffixTable(t *testing.T) {
+	testOptions := []struct {
+		table    SuffixTable
+		suffixes []string
+		word     string
+		expected string
+	}{
+		{SuffixNoun, []string{"ы"}, "вагоны", "вагон"},
+		{SuffixNoun, []string{"ы"}, "вагона", "вагона"},
+		{SuffixDerivational, nil, "бдительность", "бдительност"},
+		{SuffixSuperlative, nil, "важнейш", "важнейш"},
+		{SuffixVerb, []string{"ал"}, "читал", "чит"},
+		{SuffixAdjective, []string{"его"}, "синего", "син"},
+		{SuffixAdjective, []string{"его"}, "важная", "важна"},
+		{SuffixAdjective, []string{"его"}, "читающего", "чита"},
+	}
+
+	for _, test := range testOptions {
+		stemmer := New(WithReplaceSuffixTable(test.table, test.suffixes))
+		if base := stemmer.GetWordBase(test.word); base != test.expected {
+			t.Errorf("Not equal: [%s] %s != %s", test.word, test.expected, base)
+		}
+	}
+
+	// The package-level tables are not modified.
+	testWords := map[string]string{
+		"вагона"       : "вагон",
+		"бдительность" : "бдительн",
+		"читал"        : "чита",
+	}
+	for word, base := range testWords {
+		if testBase := New().GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+}
+
+func TestSuffixTries(t *testing.T) {
+	// The tries find the same suffixes as a scan of the tables from the longest suffix.
+	tables := [][]string{
+		suffixPerfectiveGerunds[1], suffixAdjective, suffixVerb[1], suffixNoun, suffixParticiple[0], suffixParticiple[1],
+	}
+	words := readLines(t, "testdata/snowball/voc.txt")
+
+	for _, table := range tables {
+		trie := porter.NewTrie(table)
+		for _, word := range words {
+			expected := 0
+			for _, suffix := range table {
+				if strings.HasSuffix(word, suffix) {
+					expected = utf8.RuneCountInString(suffix)
+					break
+				}
+			}
+			if n := trie.Match([]rune(word), ""); n != expected {
+				t.Errorf("Not equal: [%s] %d != %d", word, expected, n)
+			}
+		}
+	}
+}
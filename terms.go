@@ -0,0 +1,196 @@
+package rustemmer
+
+import (
+	"sort"
+)
+
+// TermCount is a base word together with the number of its occurrences in a text.
+type TermCount struct {
+	Stem  string
+	Count int
+}
+
+// TopTerms returns the n most frequent base words of the text.
+func TopTerms(text string, n int) []TermCount {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.TopTerms(text, n)
+}
+
+// TopTerms returns the n most frequent base words of the text, as counted by WordFrequencies.
+// Base words with equal counts are ordered alphabetically. A non-positive n returns all base words.
+func (r *RuStemmer) TopTerms(text string, n int) []TermCount {
+	frequencies := r.WordFrequencies(text)
+
+	ret := make([]TermCount, 0, len(frequencies))
+	for stem, count := range frequencies {
+		ret = append(ret, TermCount{Stem: stem, Count: count})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return ret[i].Stem < ret[j].Stem
+	})
+
+	if n > 0 && n < len(ret) {
+		ret = ret[:n]
+	}
+
+	return ret
+}
+
+// Keyword is a base word of a text together with its most frequent form and the number of its occurrences.
+type Keyword struct {
+	// Word is the most frequent of the words of the text with the base, in lower case.
+	Word string
+	// Stem is the base of the words, in lower case.
+	Stem string
+	// Count is the number of occurrences of all the words with the base.
+	Count int
+}
+
+// TermFrequencies returns a map from each base word of the text to the number of its occurrences.
+func TermFrequencies(text string) map[string]int {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.TermFrequencies(text)
+}
+
+// TermFrequencies returns a map from each base word of the text to the number of its occurrences,
+// counted in one pass over the words as WordFrequencies does, so the stop words, the minimal word length
+// and the case handling of the stemmer are respected. The map is empty, but not nil, for a text without words.
+func (r *RuStemmer) TermFrequencies(text string) map[string]int {
+	return r.WordFrequencies(text)
+}
+
+// FormFrequencies returns a map from the most frequent form of each base word of the text to its number of occurrences.
+func FormFrequencies(text string) map[string]int {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.FormFrequencies(text)
+}
+
+// FormFrequencies returns a map from the most frequent form of each base word of the text to the number
+// of occurrences of all the words with the base, so "вагон", "вагоны" and "вагоне" are counted together
+// under the most frequent of them. Unlike TermFrequencies, the words are compared case-insensitively
+// and reported in lower case. Stop words are not counted.
+func (r *RuStemmer) FormFrequencies(text string) map[string]int {
+	keywords := r.keywords(text, nil)
+	ret := make(map[string]int, len(keywords))
+	for _, keyword := range keywords {
+		ret[keyword.Word] = keyword.Count
+	}
+
+	return ret
+}
+
+// Keywords returns the n most frequent base words of the text with their most frequent forms.
+func Keywords(text string, n int) []Keyword {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.Keywords(text, n)
+}
+
+// Keywords returns the n most frequent base words of the text with their most frequent forms,
+// counted as by FormFrequencies. The stop words set with WithStopWords are skipped, or, if there are none,
+// the built-in Russian stop words. Keywords with equal counts are ordered alphabetically by their forms.
+// A non-positive n returns all keywords.
+func (r *RuStemmer) Keywords(text string, n int) []Keyword {
+	stopWords := russianStopWordSet
+	if len(r.stopWords) > 0 {
+		// The configured stop words are already skipped when the text is split into words
+		stopWords = nil
+	}
+	ret := r.keywords(text, stopWords)
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return ret[i].Word < ret[j].Word
+	})
+
+	if n > 0 && n < len(ret) {
+		ret = ret[:n]
+	}
+
+	return ret
+}
+
+// keywords returns the base words of the text, except the stop words, with their most frequent forms.
+// Of the forms occurring equally often, the alphabetically first one is chosen.
+func (r *RuStemmer) keywords(text string, stopWords map[string]bool) []Keyword {
+	formCounts := map[string]int{}
+	formStems := map[string]string{}
+	for _, word := range r.splitWords(text) {
+		form := toLower(word)
+		if stopWords[form] {
+			continue
+		}
+		if _, ok := formStems[form]; !ok {
+			formStems[form] = r.GetWordBase(form)
+		}
+		formCounts[form]++
+	}
+
+	groups := map[string]*Keyword{}
+	formCount := map[string]int{}
+	for form, count := range formCounts {
+		stem := formStems[form]
+		keyword, ok := groups[stem]
+		if !ok {
+			keyword = &Keyword{Stem: stem}
+			groups[stem] = keyword
+		}
+		keyword.Count += count
+		if count > formCount[stem] || count == formCount[stem] && form < keyword.Word {
+			keyword.Word, formCount[stem] = form, count
+		}
+	}
+
+	ret := make([]Keyword, 0, len(groups))
+	for _, keyword := range groups {
+		ret = append(ret, *keyword)
+	}
+
+	return ret
+}
+
+// UniqueStems returns the distinct base words of the text in order of their first occurrence.
+func UniqueStems(text string) []string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.UniqueStems(text)
+}
+
+// UniqueStemsSorted returns the distinct base words of the text in alphabetical order.
+func UniqueStemsSorted(text string) []string {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.UniqueStemsSorted(text)
+}
+
+// UniqueStems returns the distinct base words of the text in order of their first occurrence,
+// such as the terms of a document for the postings of an inverted index. Stop words are skipped.
+// The slice is empty, but not nil, for a text without words.
+func (r *RuStemmer) UniqueStems(text string) []string {
+	stems := []string{}
+	seen := map[string]bool{}
+	r.ForEachStem(text, func(stem string) bool {
+		if !seen[stem] {
+			seen[stem] = true
+			stems = append(stems, stem)
+		}
+		return true
+	})
+
+	return stems
+}
+
+// UniqueStemsSorted returns the distinct base words of the text, as UniqueStems does, in alphabetical order.
+func (r *RuStemmer) UniqueStemsSorted(text string) []string {
+	stems := r.UniqueStems(text)
+	sort.Strings(stems)
+
+	return stems
+}
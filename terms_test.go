@@ -0,0 +1,127 @@
+package rustemmer
+
+import (
+	"testing"
+	"reflect"
+)
+
+func TestTopTerms(t *testing.T) {
+	text := "Вагон стоял. В вагоне были вагоны, вагонами гордились. " +
+		"Важная новость о важных вазах и вазе."
+
+	expected := []TermCount{
+		{Stem: "вагон", Count: 3},
+		{Stem: "ваз", Count: 2},
+		{Stem: "В", Count: 1},
+		{Stem: "Вагон", Count: 1},
+	}
+	if result := TopTerms(text, 4); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+
+	if result := TopTerms(text, 0); len(result) != 12 {
+		t.Errorf("Expected 12 terms, got %v", result)
+	}
+	if result := TopTerms("", 3); len(result) != 0 {
+		t.Errorf("Expected no terms, got %v", result)
+	}
+}
+
+func TestTopTermsOptions(t *testing.T) {
+	stemmer := New(WithCaseFolding(), WithStopWords([]string{"в", "и", "о"}), WithMinWordLength(3))
+	text := "Вагон и вагоны, в вагоне о вагонах"
+
+	expected := []TermCount{{Stem: "вагон", Count: 4}}
+	if result := stemmer.TopTerms(text, 10); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+}
+
+func TestTermFrequencies(t *testing.T) {
+	text := "Вагон стоял. В вагоне были вагоны, вагоны ждали. Вагоны!"
+
+	expected := map[string]int{
+		"Вагон" : 2,
+		"вагон" : 3,
+		"стоя"  : 1,
+		"В"     : 1,
+		"был"   : 1,
+		"ждал"  : 1,
+	}
+	if result := TermFrequencies(text); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+
+	stemmer := New(WithCaseFolding(), WithStopWords([]string{"в"}), WithMinWordLength(5))
+	expected = map[string]int{
+		"вагон" : 5,
+		"стоя"  : 1,
+		"были"  : 1,
+		"ждал"  : 1,
+	}
+	if result := stemmer.TermFrequencies(text); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+
+	if result := TermFrequencies(""); result == nil || len(result) != 0 {
+		t.Errorf("Expected an empty map, got %v", result)
+	}
+}
+
+func TestFormFrequencies(t *testing.T) {
+	text := "Вагон стоял. В вагоне были вагоны, вагоны ждали. Вагоны!"
+
+	expected := map[string]int{
+		"вагоны" : 5,
+		"стоял"  : 1,
+		"в"      : 1,
+		"были"   : 1,
+		"ждали"  : 1,
+	}
+	if result := FormFrequencies(text); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+
+	if result := FormFrequencies(""); result == nil || len(result) != 0 {
+		t.Errorf("Expected an empty map, got %v", result)
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	text := "Важная новость: в вагоне были вазы, а в вагонах - новые вазы. Вагон и ваза."
+
+	expected := []Keyword{
+		{Word: "вагон", Stem: "вагон", Count: 3},
+		{Word: "вазы", Stem: "ваз", Count: 3},
+		{Word: "важная", Stem: "важн", Count: 1},
+	}
+	if result := Keywords(text, 3); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Not equal: %v != %v", expected, result)
+	}
+
+	if result := New(WithStopWords([]string{"новость"})).Keywords(text, 0); len(result) != 8 {
+		t.Errorf("Expected 8 keywords, got %v", result)
+	}
+}
+
+func TestUniqueStems(t *testing.T) {
+	text := "Вагоны стоят, вагоны едут; вагоном управляет машинист. Вагоны!"
+	expected := []string{"Вагон", "сто", "вагон", "едут", "управля", "машинист"}
+	if stems := UniqueStems(text); !reflect.DeepEqual(stems, expected) {
+		t.Errorf("Not equal: %v != %v", expected, stems)
+	}
+
+	expected = []string{"Вагон", "вагон", "едут", "машинист", "сто", "управля"}
+	if stems := UniqueStemsSorted(text); !reflect.DeepEqual(stems, expected) {
+		t.Errorf("Not equal: %v != %v", expected, stems)
+	}
+
+	expected = []string{"вагон", "машинист", "сто", "управля"}
+	if stems := New(WithCaseFolding(), WithStopWords([]string{"едут"})).UniqueStemsSorted(text); !reflect.DeepEqual(stems, expected) {
+		t.Errorf("Not equal: %v != %v", expected, stems)
+	}
+
+	if stems := UniqueStems(" ,. "); stems == nil || len(stems) != 0 {
+		t.Errorf("Expected an empty slice, got %#v", stems)
+	}
+}
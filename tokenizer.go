@@ -0,0 +1,220 @@
+package rustemmer
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wordPattern is the default regular expression matching a single word of a text.
+// Combining marks are part of a word, so decomposed letters are not split off. Apostrophes followed
+// by letters, as in "д'Артаньян" or "О’Нил", are part of a word too.
+const wordPattern = "[\\p{L}\\p{M}\\d_]+(?:['’ʼ][\\p{L}\\p{M}]+)*"
+
+// wordTokenizer is the default tokenizer. It is compiled once and shared by all stemmers,
+// as a compiled regular expression is safe for concurrent use.
+var wordTokenizer = regexpTokenizer{regexp.MustCompile(wordPattern)}
+
+// Tokenizer finds the words of a text.
+type Tokenizer interface {
+	// WordIndexes returns the byte offsets of the words of the text in order of appearance,
+	// as regexp.Regexp.FindAllStringIndex does: the word k is text[indexes[k][0]:indexes[k][1]].
+	WordIndexes(text string) [][]int
+}
+
+// TokenizerFunc is an adapter to allow the use of ordinary functions as tokenizers.
+type TokenizerFunc func(text string) [][]int
+
+// WordIndexes returns f(text).
+func (f TokenizerFunc) WordIndexes(text string) [][]int {
+	return f(text)
+}
+
+// regexpTokenizer is a Tokenizer finding the words that match a regular expression.
+type regexpTokenizer struct {
+	re *regexp.Regexp
+}
+
+// WordIndexes returns the byte offsets of all matches of the regular expression in the text.
+func (t regexpTokenizer) WordIndexes(text string) [][]int {
+	return t.re.FindAllStringIndex(text, -1)
+}
+
+// WithTokenizer sets the tokenizer used to find words in a text by NormalizeText, Tokenize
+// and the other functions working with texts, replacing the default one based on a regular expression.
+// The offsets returned by the tokenizer are kept by Tokenize and NormalizeTextPreserve.
+// Stop words and non-Russian words are still skipped as configured.
+func WithTokenizer(tokenizer Tokenizer) Option {
+	return func(r *RuStemmer) {
+		r.tokenizer = tokenizer
+	}
+}
+
+// Token is a word of a text together with its base.
+type Token struct {
+	// Original is the word as it appears in the text.
+	Original string
+	// Stem is the base of the word.
+	Stem string
+	// Start and End are byte offsets of the word in the text, so text[Start:End] == Original.
+	Start int
+	End   int
+	// RuneStart and RuneEnd are the offsets of the word in the text counted in runes,
+	// as needed to highlight the word in editors and languages that index text by characters.
+	RuneStart int
+	RuneEnd   int
+}
+
+// Tokenize returns the words of the text with their bases and positions, in order of appearance.
+func Tokenize(text string) []Token {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.Tokenize(text)
+}
+
+// Tokenize returns the words of the text with their bases and positions, in order of appearance.
+func (r *RuStemmer) Tokenize(text string) []Token {
+	indexes := r.findWordIndexes(text)
+	tokens := make([]Token, len(indexes))
+	for k, loc := range indexes {
+		word := text[loc[0]:loc[1]]
+		tokens[k] = Token{
+			Original: word,
+			Stem:     r.GetWordBase(word),
+			Start:    loc[0],
+			End:      loc[1],
+		}
+	}
+	setRuneOffsets(text, tokens)
+
+	return tokens
+}
+
+// setRuneOffsets sets RuneStart and RuneEnd of the tokens from their byte offsets in the text.
+// Runes are counted from the end of the previous token, unless a tokenizer returned the words out of order.
+func setRuneOffsets(text string, tokens []Token) {
+	pos, runes := 0, 0
+	for k := range tokens {
+		if tokens[k].Start < pos {
+			pos, runes = 0, 0
+		}
+		runes += utf8.RuneCountInString(text[pos:tokens[k].Start])
+		tokens[k].RuneStart = runes
+		runes += utf8.RuneCountInString(tokens[k].Original)
+		tokens[k].RuneEnd = runes
+		pos = tokens[k].End
+	}
+}
+
+// splitWords returns the words of the text, skipping stop words and, if configured, non-Russian words.
+func (r *RuStemmer) splitWords(text string) []string {
+	indexes := r.findWordIndexes(text)
+	words := make([]string, len(indexes))
+	for k, loc := range indexes {
+		words[k] = text[loc[0]:loc[1]]
+	}
+
+	return words
+}
+
+// filtersWords reports whether some words are skipped when a text is split into words.
+func (r *RuStemmer) filtersWords() bool {
+	return len(r.stopWords) > 0 || r.dropNonRussian || r.numberPolicy == NumberDrop || r.alphanumericPolicy == NumberDrop ||
+		r.invalidWordPolicy == InvalidWordSkip
+}
+
+// charClassTokenizer is a Tokenizer finding words as maximal runs of characters satisfying a predicate.
+type charClassTokenizer struct {
+	isWordChar func(char rune) bool
+}
+
+// WordIndexes returns the byte offsets of all maximal runs of word characters in the text.
+func (t charClassTokenizer) WordIndexes(text string) [][]int {
+	var indexes [][]int
+	for start, end := nextWord(text, 0, t.isWordChar, false); start >= 0; start, end = nextWord(text, end, t.isWordChar, false) {
+		indexes = append(indexes, []int{start, end})
+	}
+
+	return indexes
+}
+
+// WithWordChars makes the stemmer find words in a text as maximal runs of the characters for which isWordChar
+// returns true, replacing a tokenizer set before it. It decides, for instance, whether apostrophes, as in
+// "д'Артаньян", or digits are part of words. By default words consist of letters, combining marks, ASCII digits
+// and "_", joined by apostrophes followed by letters. Invalid UTF-8 bytes are passed to isWordChar as utf8.RuneError.
+// Unlike WithTokenizerPattern, it lets AppendNormalized work without allocating.
+func WithWordChars(isWordChar func(char rune) bool) Option {
+	return func(r *RuStemmer) {
+		r.tokenizer = charClassTokenizer{isWordChar}
+	}
+}
+
+// nextWord returns the byte offsets of the first maximal run of word characters of the text starting
+// at or after the offset start, or -1 and -1 if there are no more words. If apostrophes is set, apostrophes
+// followed by letters are part of a word, which continues with letters and marks only, as wordPattern matches.
+// Unlike the tokenizers, it does not allocate.
+func nextWord(text string, start int, isWordChar func(char rune) bool, apostrophes bool) (int, int) {
+	begin := -1
+	lettersOnly := false
+	for i := start; i < len(text); {
+		char, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case begin < 0:
+			if isWordChar(char) {
+				begin = i
+			}
+		// "ʼ" is a letter, so, as in wordPattern, it continues a word whatever follows it
+		case apostrophes && isApostrophe(char) && !isLetterOrMark(char):
+			next, _ := utf8.DecodeRuneInString(text[i + size:])
+			if !isLetterOrMark(next) {
+				return begin, i
+			}
+			lettersOnly = true
+		case !isWordChar(char) || lettersOnly && !isLetterOrMark(char):
+			return begin, i
+		}
+		i += size
+	}
+	if begin < 0 {
+		return -1, -1
+	}
+
+	return begin, len(text)
+}
+
+// isWordChar reports whether the character may form a word of wordPattern: a letter, a mark, an ASCII digit or "_".
+func isWordChar(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsMark(char) || char >= '0' && char <= '9' || char == '_'
+}
+
+// isLetterOrMark reports whether the character is a letter or a mark, which may follow an apostrophe in a word.
+func isLetterOrMark(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsMark(char)
+}
+
+// isApostrophe reports whether the character is an apostrophe or a right single quotation mark,
+// which are part of a word when followed by letters.
+func isApostrophe(char rune) bool {
+	return char == '\'' || char == '’' || char == 'ʼ'
+}
+
+// findWordIndexes returns the byte offsets of the words of the text, split and filtered
+// as configured with the number policies, skipping stop words and, if configured, non-Russian words.
+func (r *RuStemmer) findWordIndexes(text string) [][]int {
+	indexes := r.tokenizer.WordIndexes(text)
+	if r.alphanumericPolicy == NumberSplit {
+		indexes = splitAlphanumeric(text, indexes)
+	}
+	if !r.filtersWords() {
+		return indexes
+	}
+
+	ret := indexes[:0]
+	for _, loc := range indexes {
+		if !r.skipWord(text[loc[0]:loc[1]]) {
+			ret = append(ret, loc)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,181 @@
+package rustemmer
+
+import (
+	"regexp"
+	"testing"
+	"unicode"
+	"reflect"
+)
+
+func TestTokenize(t *testing.T) {
+	text := "Важная новость (!) — в вагоне; 31А, Wi-Fi…"
+	expected := []Token{
+		{Original: "Важная", Stem: "Важн", Start: 0, End: 12, RuneStart: 0, RuneEnd: 6},
+		{Original: "новость", Stem: "новост", Start: 13, End: 27, RuneStart: 7, RuneEnd: 14},
+		{Original: "в", Stem: "в", Start: 36, End: 38, RuneStart: 21, RuneEnd: 22},
+		{Original: "вагоне", Stem: "вагон", Start: 39, End: 51, RuneStart: 23, RuneEnd: 29},
+		{Original: "31А", Stem: "31А", Start: 53, End: 57, RuneStart: 31, RuneEnd: 34},
+		{Original: "Wi", Stem: "Wi", Start: 59, End: 61, RuneStart: 36, RuneEnd: 38},
+		{Original: "Fi", Stem: "Fi", Start: 62, End: 64, RuneStart: 39, RuneEnd: 41},
+	}
+
+	tokens := Tokenize(text)
+	if !reflect.DeepEqual(expected, tokens) {
+		t.Errorf("Not equal: %v != %v", expected, tokens)
+	}
+
+	for _, token := range tokens {
+		if original := text[token.Start:token.End]; original != token.Original {
+			t.Errorf("Not equal: %s != %s", token.Original, original)
+		}
+		if original := string([]rune(text)[token.RuneStart:token.RuneEnd]); original != token.Original {
+			t.Errorf("Not equal: %s != %s", token.Original, original)
+		}
+	}
+}
+
+func TestTokenizeEmpty(t *testing.T) {
+	if tokens := Tokenize(" ,.! "); len(tokens) != 0 {
+		t.Errorf("Expected no tokens, got %v", tokens)
+	}
+}
+
+func TestTokenizeMultibyteOffsets(t *testing.T) {
+	text := "Ёжик — в тумане"
+	expected := []Token{
+		{Original: "Ёжик", Stem: "Ёжик", Start: 0, End: 8, RuneStart: 0, RuneEnd: 4},
+		{Original: "в", Stem: "в", Start: 13, End: 15, RuneStart: 7, RuneEnd: 8},
+		{Original: "тумане", Stem: "туман", Start: 16, End: 28, RuneStart: 9, RuneEnd: 15},
+	}
+
+	if tokens := Tokenize(text); !reflect.DeepEqual(expected, tokens) {
+		t.Errorf("Not equal: %v != %v", expected, tokens)
+	}
+}
+
+func TestWithTokenizer(t *testing.T) {
+	text := "Ремонт д'Артаньяна: 25 вагонов и 3-х комнатные квартиры"
+
+	hyphens := regexp.MustCompile("[\\p{L}\\p{M}\\d_'-]+")
+	stemmer := New(WithTokenizer(TokenizerFunc(func(text string) [][]int {
+		return hyphens.FindAllStringIndex(text, -1)
+	})))
+	expected := "Ремонт д'Артанья 25 вагон и 3-х комнатн квартир"
+	if normalized := stemmer.NormalizeText(text); normalized != expected {
+		t.Errorf("Not equal: %s != %s", expected, normalized)
+	}
+
+	words := regexp.MustCompile(wordPattern)
+	digits := regexp.MustCompile("^\\d+$")
+	stemmer = New(WithTokenizer(TokenizerFunc(func(text string) [][]int {
+		indexes := [][]int{}
+		for _, loc := range words.FindAllStringIndex(text, -1) {
+			if !digits.MatchString(text[loc[0]:loc[1]]) {
+				indexes = append(indexes, loc)
+			}
+		}
+		return indexes
+	})))
+	expected = "Ремонт д'Артанья вагон и х комнатн квартир"
+	if normalized := stemmer.NormalizeText(text); normalized != expected {
+		t.Errorf("Not equal: %s != %s", expected, normalized)
+	}
+	if normalized := NormalizeText(text); normalized != "Ремонт д'Артанья 25 вагон и 3 х комнатн квартир" {
+		t.Errorf("Not equal: %s != %s", "Ремонт д'Артанья 25 вагон и 3 х комнатн квартир", normalized)
+	}
+}
+
+func TestWithTokenizerOffsets(t *testing.T) {
+	// A tokenizer that finds only the first word of every sentence.
+	stemmer := New(WithTokenizer(TokenizerFunc(func(text string) [][]int {
+		return [][]int{{0, 12}, {25, 37}}
+	})))
+	text := "Вагоны стоят. Рельсы, вагоны."
+	expected := []Token{
+		{Original: "Вагоны", Stem: "Вагон", Start: 0, End: 12, RuneStart: 0, RuneEnd: 6},
+		{Original: "Рельсы", Stem: "Рельс", Start: 25, End: 37, RuneStart: 14, RuneEnd: 20},
+	}
+
+	if tokens := stemmer.Tokenize(text); !reflect.DeepEqual(expected, tokens) {
+		t.Errorf("Not equal: %v != %v", expected, tokens)
+	}
+	if preserved := stemmer.NormalizeTextPreserve(text); preserved != "Вагон стоят. Рельс, вагоны." {
+		t.Errorf("Not equal: %s != %s", "Вагон стоят. Рельс, вагоны.", preserved)
+	}
+}
+
+func TestTokenizeUnorderedRuneOffsets(t *testing.T) {
+	stemmer := New(WithTokenizer(TokenizerFunc(func(text string) [][]int {
+		return [][]int{{16, 24}, {0, 12}}
+	})))
+	expected := []Token{
+		{Original: "депо", Stem: "деп", Start: 16, End: 24, RuneStart: 9, RuneEnd: 13},
+		{Original: "Вагоны", Stem: "Вагон", Start: 0, End: 12, RuneStart: 0, RuneEnd: 6},
+	}
+
+	if tokens := stemmer.Tokenize("Вагоны в депо"); !reflect.DeepEqual(expected, tokens) {
+		t.Errorf("Not equal: %v != %v", expected, tokens)
+	}
+}
+
+func TestWithWordChars(t *testing.T) {
+	stemmer := New(WithWordChars(func(char rune) bool {
+		return unicode.IsLetter(char) || char == '\'' || char == '’'
+	}))
+
+	text := "д'Артаньяна и д’Артаньяном, snake_case 31А"
+	if normalized := stemmer.NormalizeText(text); normalized != "д'Артанья и д’Артаньян snake case А" {
+		t.Errorf("Not equal: д'Артанья и д’Артаньян snake case А != %s", normalized)
+	}
+	if appended := stemmer.AppendNormalized(nil, text); string(appended) != stemmer.NormalizeText(text) {
+		t.Errorf("Not equal: %s != %s", stemmer.NormalizeText(text), appended)
+	}
+
+	tokens := stemmer.Tokenize(text)
+	if len(tokens) != 6 || tokens[2].Original != "д’Артаньяном" || text[tokens[2].Start:tokens[2].End] != tokens[2].Original {
+		t.Errorf("Unexpected tokens %v", tokens)
+	}
+
+	if tokens := New(WithWordChars(unicode.IsLetter)).Tokenize(" ,. "); len(tokens) != 0 {
+		t.Errorf("Expected no tokens, got %v", tokens)
+	}
+}
+
+func TestApostrophes(t *testing.T) {
+	testTexts := map[string][]string{
+		"О’Нил и д'Артаньяна"           : {"О’Нил", "и", "д'Артаньяна"},
+		"«об’єкт» и обʼекта"             : {"об’єкт", "и", "обʼекта"},
+		"'вагоны' стоят’ ’депо"          : {"вагоны", "стоят", "депо"},
+		"д''Артаньян д'1 д'Арт1 О’Нил’а" : {"д", "Артаньян", "д", "1", "д'Арт", "1", "О’Нил’а"},
+		"’"                              : {},
+		"стоятʼ 1ʼ"                      : {"стоятʼ", "1ʼ"},
+	}
+
+	for text, expected := range testTexts {
+		words := []string{}
+		for _, token := range Tokenize(text) {
+			words = append(words, token.Original)
+		}
+		if !reflect.DeepEqual(words, expected) {
+			t.Errorf("Not equal: %q != %q", expected, words)
+		}
+
+		if normalized, expected := string(AppendNormalized(nil, text)), NormalizeText(text); normalized != expected {
+			t.Errorf("Not equal: %s != %s", expected, normalized)
+		}
+	}
+
+	testWords := map[string]string{
+		"д’Артаньяном" : "д’Артаньян",
+		"О’Нилом"      : "О’Нил",
+		"обʼекта"      : "обʼект",
+	}
+	for word, expected := range testWords {
+		if base := GetWordBase(word); base != expected {
+			t.Errorf("Not equal: %s != %s", expected, base)
+		}
+	}
+	if normalized := NormalizeText("Встреча с д’Артаньяном"); normalized != "Встреч с д’Артаньян" {
+		t.Errorf("Not equal: Встреч с д’Артаньян != %s", normalized)
+	}
+}
@@ -0,0 +1,244 @@
+package rustemmer
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/liderman/rustemmer/internal/porter"
+)
+
+// Names of the algorithm steps reported in StepTrace.
+const (
+	StepPerfectiveGerund = "perfective gerund"
+	StepReflexive        = "reflexive"
+	StepAdjectival       = "adjectival"
+	StepVerb             = "verb"
+	StepNoun             = "noun"
+	StepI                = "и"
+	StepDerivational     = "derivational"
+	StepNN               = "нн"
+	StepSuperlative      = "superlative"
+	StepSoftSign         = "soft sign"
+	StepPrefix           = "prefix"
+)
+
+// Suffix classes reported in StemResult. They are named as the steps removing the suffixes.
+const (
+	ClassNone             = ""
+	ClassPerfectiveGerund = StepPerfectiveGerund
+	ClassReflexive        = StepReflexive
+	ClassAdjectival       = StepAdjectival
+	ClassVerb             = StepVerb
+	ClassNoun             = StepNoun
+	ClassI                = StepI
+	ClassDerivational     = StepDerivational
+	ClassSuperlative      = StepSuperlative
+	ClassNN               = StepNN
+	ClassSoftSign         = StepSoftSign
+)
+
+// stepNumbers maps the names of the Porter steps to their numbers.
+var stepNumbers = map[string]int{
+	StepPerfectiveGerund : 1,
+	StepReflexive        : 1,
+	StepAdjectival       : 1,
+	StepVerb             : 1,
+	StepNoun             : 1,
+	StepI                : 2,
+	StepDerivational     : 3,
+	StepSuperlative      : 4,
+	StepNN               : 4,
+	StepSoftSign         : 4,
+}
+
+// StemResult is the base of a word together with the suffix that determines its part of speech.
+type StemResult struct {
+	// Stem is the base of the word, as returned by GetWordBase.
+	Stem string
+	// RemovedSuffix is the suffix of the class SuffixClass removed from the word.
+	// At step 1 it includes the reflexive ending removed along with the suffix, such as "вшийся".
+	RemovedSuffix string
+	// SuffixClass is one of the Class constants: the class of the ending removed at step 1,
+	// or, if step 1 removed nothing, of the first suffix removed by a later step.
+	// It is ClassNone if the Porter steps left the word unchanged.
+	SuffixClass string
+	// Step is the number, from 1 to 4, of the Porter step that removed the suffix, or 0 for ClassNone.
+	Step int
+}
+
+// GetWordBaseDetailed returns the base of the word together with the removed suffix and its class.
+func GetWordBaseDetailed(word string) StemResult {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.GetWordBaseDetailed(word)
+}
+
+// GetWordBaseDetailed returns the base of the word together with the removed suffix and its class,
+// which may serve as a hint of the part of speech of the word. Prefixes removed with WithPrefixStripping are not reported.
+// The base is found as GetWordBase finds it, except that the cache is not read. Words whose bases come from
+// the dictionary, the exceptions or the lemmas go through no Porter steps and have the class ClassNone.
+func (r *RuStemmer) GetWordBaseDetailed(word string) StemResult {
+	trace := []StepTrace{}
+	result := StemResult{Stem: r.getWordBase(word, &trace)}
+	for _, step := range trace {
+		number, ok := stepNumbers[step.Step]
+		if !ok || result.Step != 0 && result.Step != number {
+			continue
+		}
+		if result.SuffixClass == ClassNone || result.SuffixClass == ClassReflexive && number == 1 {
+			result.SuffixClass = step.Step
+			result.RemovedSuffix = step.Suffix + result.RemovedSuffix
+			result.Step = number
+		}
+	}
+
+	return result
+}
+
+// StepTrace describes a step of the algorithm that changed the word.
+type StepTrace struct {
+	// Step is the name of the step, one of the Step constants.
+	Step string
+	// Suffix is the removed suffix, or the removed prefix for StepPrefix.
+	Suffix string
+	// Word is the word after the step.
+	Word string
+}
+
+// GetWordBaseTrace returns the base word together with the steps that changed it, in order.
+func GetWordBaseTrace(word string) (string, []StepTrace) {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.GetWordBaseTrace(word)
+}
+
+// GetWordBaseTrace returns the base word together with the steps that changed it, in order.
+// The base is found as GetWordBase finds it, except that the cache is not read, so every step is reported.
+// Words whose bases come from the dictionary, the exceptions or the lemmas go through no Porter steps
+// and have no steps. For a word with a non-Cyrillic prefix only its Cyrillic tail is stemmed and reported.
+func (r *RuStemmer) GetWordBaseTrace(word string) (string, []StepTrace) {
+	trace := []StepTrace{}
+	base := r.getWordBase(word, &trace)
+	return base, trace
+}
+
+// StemTrace returns the base word together with the names of the steps that changed it, in order.
+func StemTrace(word string) (string, []string) {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.StemTrace(word)
+}
+
+// StemTrace returns the base word together with the names of the steps that changed it, in order,
+// as GetWordBaseTrace does, but without the removed suffixes. The names are the Step constants.
+func (r *RuStemmer) StemTrace(word string) (string, []string) {
+	base, trace := r.GetWordBaseTrace(word)
+	steps := make([]string, len(trace))
+	for k, step := range trace {
+		steps[k] = step.Step
+	}
+
+	return base, steps
+}
+
+// Explanation describes how a word was stemmed, for debugging and issue reports.
+type Explanation struct {
+	// Word is the word as given.
+	Word string
+	// Prepared is the word after the configured normalization, such as case folding.
+	Prepared string
+	// RV and R2 are the rune offsets in Prepared where the regions of the Porter steps start.
+	// They are computed for the trailing Cyrillic part of the word, the only part that is stemmed,
+	// and are equal to the length of Prepared if the word has no such part.
+	RV int
+	R2 int
+	// Steps are the steps that changed the word, in order, as returned by GetWordBaseTrace.
+	Steps []StepTrace
+	// Stem is the base of the word.
+	Stem string
+}
+
+// Explain returns a description of how the word is stemmed.
+func Explain(word string) Explanation {
+	r := getPooled()
+	defer Pool.Put(r)
+	return r.Explain(word)
+}
+
+// Explain returns a description of how the word is stemmed: the regions of the word and the steps
+// that changed it, as GetWordBaseTrace reports them. The stem is that of GetWordBase, so words whose
+// bases come from the dictionary, the exceptions or the lemmas have no steps.
+func (r *RuStemmer) Explain(word string) Explanation {
+	e := Explanation{Word: word, Prepared: word}
+	e.Stem, e.Steps = r.GetWordBaseTrace(word)
+	if !r.isPassedThrough(word) {
+		e.Prepared = r.prepareWord(word)
+	}
+
+	head, tail := porter.SplitCyrillicTail(e.Prepared)
+	e.RV, _, e.R2 = findRegions([]rune(tail))
+	offset := utf8.RuneCountInString(head)
+	e.RV += offset
+	e.R2 += offset
+
+	return e
+}
+
+// String formats the explanation as several lines: the word and its base, the prepared word,
+// the regions, with "|" marking where they start, and the steps with the removed suffixes.
+func (e Explanation) String() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s -> %s\n", e.Word, e.Stem)
+	fmt.Fprintf(&buf, "prepared: %s\n", e.Prepared)
+	fmt.Fprintf(&buf, "RV: %d %s\n", e.RV, markRegion(e.Prepared, e.RV))
+	fmt.Fprintf(&buf, "R2: %d %s\n", e.R2, markRegion(e.Prepared, e.R2))
+	for _, step := range e.Steps {
+		if step.Step == StepPrefix {
+			fmt.Fprintf(&buf, "%s: %s- -> %s\n", step.Step, step.Suffix, step.Word)
+		} else {
+			fmt.Fprintf(&buf, "%s: -%s -> %s\n", step.Step, step.Suffix, step.Word)
+		}
+	}
+
+	return buf.String()
+}
+
+// markRegion returns the word with "|" inserted at the rune offset.
+func markRegion(word string, offset int) string {
+	runes := []rune(word)
+	if offset > len(runes) {
+		offset = len(runes)
+	}
+
+	return string(runes[:offset]) + "|" + string(runes[offset:])
+}
+
+// traceStep records a step that removed the suffix, if tracing is enabled.
+func (r *RuStemmer) traceStep(step, suffix string) {
+	if r.trace == nil {
+		return
+	}
+
+	*r.trace = append(*r.trace, StepTrace{
+		Step:   step,
+		Suffix: suffix,
+		Word:   string(r.word),
+	})
+}
+
+// traceLen returns the number of the recorded steps, or 0 if tracing is disabled.
+func (r *RuStemmer) traceLen() int {
+	if r.trace == nil {
+		return 0
+	}
+
+	return len(*r.trace)
+}
+
+// truncateTrace drops the steps recorded after the first n, if tracing is enabled.
+func (r *RuStemmer) truncateTrace(n int) {
+	if r.trace != nil {
+		*r.trace = (*r.trace)[:n]
+	}
+}
@@ -0,0 +1,182 @@
+package rustemmer
+
+import (
+	"testing"
+	"reflect"
+	"strings"
+)
+
+func TestGetWordBaseTrace(t *testing.T) {
+	testWords := map[string][]StepTrace{
+		"делавшийся" : {
+			{Step: StepReflexive, Suffix: "ся", Word: "делавший"},
+			{Step: StepAdjectival, Suffix: "вший", Word: "дела"},
+		},
+		"быстрейший" : {
+			{Step: StepAdjectival, Suffix: "ий", Word: "быстрейш"},
+			{Step: StepSuperlative, Suffix: "ейш", Word: "быстр"},
+		},
+		"важности" : {
+			{Step: StepNoun, Suffix: "и", Word: "важност"},
+		},
+		"вал" : {},
+	}
+
+	for word, expected := range testWords {
+		base, trace := GetWordBaseTrace(word)
+		if !reflect.DeepEqual(expected, trace) {
+			t.Errorf("Not equal: [%s] %v != %v", word, expected, trace)
+		}
+		if testBase := GetWordBase(word); base != testBase {
+			t.Errorf("Not equal: [%s] %s != %s", word, testBase, base)
+		}
+	}
+}
+
+func TestGetWordBaseTraceOptions(t *testing.T) {
+	lemmas, err := LoadLemmaDictionary(strings.NewReader("стали\tстать\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testStemmers := []*RuStemmer{
+		New(WithExceptions(map[string]string{"люди": "человек"})),
+		New(WithDictionary(BuildDictionary([]string{"вазы"}))),
+		New(WithLemmaDictionary(lemmas)),
+		New(WithCompoundWordSplitting(CompoundStemWhole)),
+		New(WithCompoundWordSplitting(CompoundKeepAsIs)),
+		New(WithCompoundWordSplitting(CompoundStemParts)),
+		New(WithInvalidWordReplacement("<unk>")),
+		New(WithNonRussianStemmer(upperStemmer{})),
+	}
+	words := []string{"люди", "вазы", "стали", "интернет-магазины", "вагоны", "ва\xffзы", "trains"}
+
+	for _, stemmer := range testStemmers {
+		for _, word := range words {
+			if base, _ := stemmer.GetWordBaseTrace(word); base != stemmer.GetWordBase(word) {
+				t.Errorf("Not equal: [%s] %s != %s", word, stemmer.GetWordBase(word), base)
+			}
+		}
+	}
+
+	base, trace := New(WithExceptions(map[string]string{"люди": "человек"})).GetWordBaseTrace("люди")
+	if base != "человек" || len(trace) != 0 {
+		t.Errorf("Not equal: человек [] != %s %v", base, trace)
+	}
+}
+
+func TestGetWordBaseTraceSuperlativeNN(t *testing.T) {
+	_, trace := GetWordBaseTrace("ценнейший")
+	expected := []StepTrace{
+		{Step: StepAdjectival, Suffix: "ий", Word: "ценнейш"},
+		{Step: StepSuperlative, Suffix: "ейш", Word: "ценн"},
+		{Step: StepNN, Suffix: "н", Word: "цен"},
+	}
+	if !reflect.DeepEqual(expected, trace) {
+		t.Errorf("Not equal: %v != %v", expected, trace)
+	}
+}
+
+func TestStemTrace(t *testing.T) {
+	testWords := map[string]struct {
+		base  string
+		steps []string
+	}{
+		"прочитав"     : {"прочита", []string{StepPerfectiveGerund}},
+		"вагоны"       : {"вагон", []string{StepNoun}},
+		"ценнейший"    : {"цен", []string{StepAdjectival, StepSuperlative, StepNN}},
+		"поставили"    : {"постав", []string{StepVerb}},
+		"бдительность" : {"бдительн", []string{StepNoun, StepDerivational}},
+		"вал"          : {"вал", []string{}},
+	}
+
+	for word, expected := range testWords {
+		base, steps := StemTrace(word)
+		if base != expected.base || !reflect.DeepEqual(expected.steps, steps) {
+			t.Errorf("Not equal: [%s] %s %v != %s %v", word, expected.base, expected.steps, base, steps)
+		}
+	}
+
+	// An exception is reported as GetWordBase returns it, without steps.
+	base, steps := New(WithExceptions(map[string]string{"люди": "человек"})).StemTrace("люди")
+	if base != "человек" || len(steps) != 0 {
+		t.Errorf("Not equal: человек [] != %s %v", base, steps)
+	}
+}
+
+func TestGetWordBaseDetailed(t *testing.T) {
+	testWords := map[string]StemResult{
+		"делавшийся" : {Stem: "дела", RemovedSuffix: "вшийся", SuffixClass: ClassAdjectival, Step: 1},
+		"прочитав"   : {Stem: "прочита", RemovedSuffix: "в", SuffixClass: ClassPerfectiveGerund, Step: 1},
+		"ценнейший"  : {Stem: "цен", RemovedSuffix: "ий", SuffixClass: ClassAdjectival, Step: 1},
+		"поставили"  : {Stem: "постав", RemovedSuffix: "или", SuffixClass: ClassVerb, Step: 1},
+		"вагоны"     : {Stem: "вагон", RemovedSuffix: "ы", SuffixClass: ClassNoun, Step: 1},
+		"валялся"    : {Stem: "валя", RemovedSuffix: "лся", SuffixClass: ClassVerb, Step: 1},
+		"старейш"    : {Stem: "стар", RemovedSuffix: "ейш", SuffixClass: ClassSuperlative, Step: 4},
+		"вагон"      : {Stem: "вагон"},
+		""           : {},
+	}
+
+	for word, expected := range testWords {
+		if result := GetWordBaseDetailed(word); result != expected {
+			t.Errorf("Not equal: [%s] %+v != %+v", word, expected, result)
+		}
+		if base := GetWordBase(word); base != expected.Stem {
+			t.Errorf("Not equal: [%s] %s != %s", word, expected.Stem, base)
+		}
+	}
+
+	// The exceptions and the cache are consulted as by GetWordBase,
+	// and a cached word still reports its suffix.
+	stemmer := New(WithCache(10))
+	stemmer.AddException("люди", "человек")
+	testWords = map[string]StemResult{
+		"люди"   : {Stem: "человек"},
+		"стоят"  : {Stem: "сто", RemovedSuffix: "ят", SuffixClass: ClassVerb, Step: 1},
+	}
+	for word, expected := range testWords {
+		stemmer.GetWordBase(word)
+		if result := stemmer.GetWordBaseDetailed(word); result != expected {
+			t.Errorf("Not equal: [%s] %+v != %+v", word, expected, result)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	e := New(WithCaseFolding()).Explain("Бдительность")
+	expected := Explanation{
+		Word:     "Бдительность",
+		Prepared: "бдительность",
+		RV:       3,
+		R2:       6,
+		Steps:    []StepTrace{
+			{Step: StepNoun, Suffix: "ь", Word: "бдительност"},
+			{Step: StepDerivational, Suffix: "ост", Word: "бдительн"},
+		},
+		Stem:     "бдительн",
+	}
+	if !reflect.DeepEqual(expected, e) {
+		t.Errorf("Not equal: %v != %v", expected, e)
+	}
+
+	lines := "Бдительность -> бдительн\n" +
+		"prepared: бдительность\n" +
+		"RV: 3 бди|тельность\n" +
+		"R2: 6 бдител|ьность\n" +
+		"noun: -ь -> бдительност\n" +
+		"derivational: -ост -> бдительн\n"
+	if e.String() != lines {
+		t.Errorf("Not equal: %s != %s", lines, e.String())
+	}
+
+	if e := Explain("windows10-вагоны"); e.RV != 12 || e.R2 != 15 || e.Stem != "windows10-вагон" {
+		t.Errorf("Not equal: [12 15 windows10-вагон] != [%d %d %s]", e.RV, e.R2, e.Stem)
+	}
+
+	stemmer := New(WithExceptions(map[string]string{"люди": "человек"}), WithDictionary(BuildDictionary([]string{"вазы"})))
+	for word, expected := range map[string]string{"люди": "человек", "вазы": "ваз"} {
+		if e := stemmer.Explain(word); e.Stem != expected || len(e.Steps) != 0 {
+			t.Errorf("Not equal: [%s] %s [] != %s %v", word, expected, e.Stem, e.Steps)
+		}
+	}
+}
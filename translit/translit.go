@@ -0,0 +1,160 @@
+// Package translit transliterates Russian text and word bases between Cyrillic and Latin letters.
+//
+// Combined with rustemmer.WithOutputTransform, it makes a stemmer emit ASCII bases:
+//
+//	stemmer := rustemmer.New(rustemmer.WithOutputTransform(translit.GOST.Latin))
+//	stemmer.NormalizeText("Важные новости") // "Vazhn novost"
+package translit
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Scheme is a system of transliteration of Russian letters.
+type Scheme int
+
+const (
+	// GOST is System B of GOST 7.79-2000, written in ASCII only and reversible: "щ" is written "shh",
+	// "ы" "y`", "э" "e`", "ъ" "``" and "ь" "`", and "ц" is written "c" before "е", "и", "ы" and "й" and "cz" otherwise.
+	GOST Scheme = iota
+	// BGN is the BGN/PCGN romanization in ASCII: "е" is written "ye" at the start of a word and after a vowel,
+	// "ё" "yo", and the hard and soft signs are omitted, so it is easier to read than GOST but loses letters.
+	BGN
+)
+
+// latinTables map the lower case Russian letters to their Latin spellings in each scheme.
+var latinTables = [...]map[rune]string{
+	GOST: {
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z", 'и': "i", 'й': "j",
+		'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+		'х': "x", 'ц': "cz", 'ч': "ch", 'ш': "sh", 'щ': "shh", 'ъ': "``", 'ы': "y`", 'ь': "`", 'э': "e`", 'ю': "yu", 'я': "ya",
+	},
+	BGN: {
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z", 'и': "i", 'й': "y",
+		'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+		'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	},
+}
+
+// cyrillicTables map the lower case Latin spellings of each scheme back to Russian letters.
+// The letters BGN spells the same way are resolved by their position in cyrillicLetter.
+var cyrillicTables = [...]map[string]rune{
+	GOST: {
+		"a": 'а', "b": 'б', "v": 'в', "g": 'г', "d": 'д', "e": 'е', "yo": 'ё', "zh": 'ж', "z": 'з', "i": 'и', "j": 'й',
+		"k": 'к', "l": 'л', "m": 'м', "n": 'н', "o": 'о', "p": 'п', "r": 'р', "s": 'с', "t": 'т', "u": 'у', "f": 'ф',
+		"x": 'х', "c": 'ц', "cz": 'ц', "ch": 'ч', "sh": 'ш', "shh": 'щ', "``": 'ъ', "y`": 'ы', "y": 'ы', "`": 'ь',
+		"e`": 'э', "yu": 'ю', "ya": 'я',
+	},
+	BGN: {
+		"a": 'а', "b": 'б', "v": 'в', "g": 'г', "d": 'д', "e": 'е', "ye": 'е', "yo": 'ё', "zh": 'ж', "z": 'з', "i": 'и',
+		"y": 'ы', "k": 'к', "l": 'л', "m": 'м', "n": 'н', "o": 'о', "p": 'п', "r": 'р', "s": 'с', "t": 'т', "u": 'у',
+		"f": 'ф', "kh": 'х', "ts": 'ц', "ch": 'ч', "sh": 'ш', "shch": 'щ', "yu": 'ю', "ya": 'я',
+	},
+}
+
+// maxSpellingLength is the length of the longest Latin spelling of a letter in any scheme.
+const maxSpellingLength = 4
+
+// vowels lists the Russian vowels, after which BGN writes "е" as "ye" and "y" stands for "й".
+const vowels = "аеёиоуыэюя"
+
+// Latin returns the text with the Russian letters replaced with their Latin spellings in the scheme.
+// Other characters are kept. The spelling of a capital letter is capitalized, or written in upper case
+// if a neighbouring letter is also a capital, so "Щука" becomes "Shhuka" and "ЩИ" becomes "SHHI" in GOST.
+func (s Scheme) Latin(text string) string {
+	table := latinTables[s]
+
+	var buf strings.Builder
+	buf.Grow(len(text))
+	var prev rune
+	for i := 0; i < len(text); {
+		char, size := utf8.DecodeRuneInString(text[i:])
+		next, _ := utf8.DecodeRuneInString(text[i + size:])
+		i += size
+
+		lower := unicode.ToLower(char)
+		spelling, ok := table[lower]
+		if !ok {
+			buf.WriteRune(char)
+			prev = char
+			continue
+		}
+
+		switch {
+		case s == GOST && lower == 'ц' && strings.ContainsRune("еиый", unicode.ToLower(next)):
+			spelling = "c"
+		case s == BGN && lower == 'е' && (!unicode.IsLetter(prev) || strings.ContainsRune(vowels + "ъьй", unicode.ToLower(prev))):
+			spelling = "ye"
+		}
+		if lower != char {
+			spelling = capitalize(spelling, unicode.IsUpper(prev) || unicode.IsUpper(next))
+		}
+		buf.WriteString(spelling)
+		prev = char
+	}
+
+	return buf.String()
+}
+
+// Cyrillic returns the text with the Latin spellings of the scheme replaced with Russian letters.
+// Other characters are kept, and the longest spelling is matched first. GOST text is restored exactly,
+// while for BGN, which spells several letters the same, "e" at the start of a word becomes "э",
+// "y" after a vowel becomes "й", and the omitted hard and soft signs are not restored.
+func (s Scheme) Cyrillic(text string) string {
+	table := cyrillicTables[s]
+
+	var buf strings.Builder
+	buf.Grow(len(text) * 2)
+	var prev rune
+	for i := 0; i < len(text); {
+		n := maxSpellingLength
+		if n > len(text) - i {
+			n = len(text) - i
+		}
+		for ; n > 0; n-- {
+			if _, ok := table[strings.ToLower(text[i:i + n])]; ok {
+				break
+			}
+		}
+		if n == 0 {
+			char, size := utf8.DecodeRuneInString(text[i:])
+			buf.WriteRune(char)
+			prev = char
+			i += size
+			continue
+		}
+
+		spelling := strings.ToLower(text[i:i + n])
+		letter := table[spelling]
+		if s == BGN {
+			switch {
+			case spelling == "e" && !unicode.IsLetter(prev):
+				letter = 'э'
+			case spelling == "y" && strings.ContainsRune(vowels, unicode.ToLower(prev)):
+				letter = 'й'
+			}
+		}
+		if unicode.IsUpper(rune(text[i])) {
+			letter = unicode.ToUpper(letter)
+		}
+		buf.WriteRune(letter)
+		prev = letter
+		i += n
+	}
+
+	return buf.String()
+}
+
+// capitalize returns the spelling with its first letter in upper case, or entirely in upper case if all is true.
+func capitalize(spelling string, all bool) string {
+	if all {
+		return strings.ToUpper(spelling)
+	}
+	if spelling == "" {
+		return spelling
+	}
+
+	return strings.ToUpper(spelling[:1]) + spelling[1:]
+}
@@ -0,0 +1,76 @@
+package translit
+
+import (
+	"testing"
+
+	"github.com/liderman/rustemmer"
+)
+
+func TestLatin(t *testing.T) {
+	testTexts := map[Scheme]map[string]string{
+		GOST: {
+			"Москва, ул. Полярная" : "Moskva, ul. Polyarnaya",
+			"щука и ЩИ"            : "shhuka i SHHI",
+			"цирк и улица"         : "cirk i ulicza",
+			"объём, сыр, эхо, мать" : "ob``yom, sy`r, e`xo, mat`",
+			"Хабаровск 2024"       : "Xabarovsk 2024",
+			"Windows"              : "Windows",
+		},
+		BGN: {
+			"Москва, ул. Полярная" : "Moskva, ul. Polyarnaya",
+			"щука и ЩИ"            : "shchuka i SHCHI",
+			"Ельцин, поезд, съел"  : "Yeltsin, poyezd, syel",
+			"Новый Хабаровск"      : "Novyy Khabarovsk",
+			"объём, мать, эхо"     : "obyom, mat, ekho",
+		},
+	}
+
+	for scheme, texts := range testTexts {
+		for text, expected := range texts {
+			if latin := scheme.Latin(text); latin != expected {
+				t.Errorf("Not equal: %s != %s", expected, latin)
+			}
+		}
+	}
+}
+
+func TestCyrillic(t *testing.T) {
+	testTexts := map[Scheme]map[string]string{
+		GOST: {
+			"Moskva, ul. Polyarnaya" : "Москва, ул. Полярная",
+			"shhuka i SHHI"          : "щука и ЩИ",
+			"cirk i ulicza"          : "цирк и улица",
+			"ob``yom, sy`r, e`xo, mat`" : "объём, сыр, эхо, мать",
+		},
+		BGN: {
+			"Moskva, ul. Polyarnaya" : "Москва, ул. Полярная",
+			"Novyy Khabarovsk"       : "Новый Хабаровск",
+			"Yelena, poyezd, eto"    : "Елена, поезд, это",
+		},
+	}
+
+	for scheme, texts := range testTexts {
+		for text, expected := range texts {
+			if cyrillic := scheme.Cyrillic(text); cyrillic != expected {
+				t.Errorf("Not equal: %s != %s", expected, cyrillic)
+			}
+		}
+	}
+}
+
+func TestGOSTRoundTrip(t *testing.T) {
+	text := "Съешь же ещё этих мягких французских булок, да выпей чаю. ЦЕНТР, Щёкино, Йошкар-Ола"
+	if restored := GOST.Cyrillic(GOST.Latin(text)); restored != text {
+		t.Errorf("Not equal: %s != %s", text, restored)
+	}
+}
+
+func TestWithOutputTransform(t *testing.T) {
+	stemmer := rustemmer.New(rustemmer.WithOutputTransform(GOST.Latin))
+	if text := stemmer.NormalizeText("Важные новости: вагоны стоят в депо"); text != "Vazhn novost vagon sto v dep" {
+		t.Errorf("Not equal: Vazhn novost vagon sto v dep != %s", text)
+	}
+	if text := string(stemmer.AppendNormalized(nil, "Важные новости")); text != "Vazhn novost" {
+		t.Errorf("Not equal: Vazhn novost != %s", text)
+	}
+}
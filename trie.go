@@ -0,0 +1,20 @@
+package rustemmer
+
+import (
+	"github.com/liderman/rustemmer/internal/porter"
+)
+
+// The tries of the built-in suffix tables are built once, as all stemmers share them.
+var triePerfectiveGerunds = newSuffixTries(suffixPerfectiveGerunds)
+var trieReflexives = porter.NewTrie(suffixReflexives)
+var trieAdjective = porter.NewTrie(suffixAdjective)
+var trieParticiple = newSuffixTries(suffixParticiple)
+var trieVerb = newSuffixTries(suffixVerb)
+var trieNoun = porter.NewTrie(suffixNoun)
+var trieSuperlative = porter.NewTrie(suffixSuperlative)
+var trieDerivational = porter.NewTrie(suffixDerivational)
+
+// newSuffixTries returns the tries of the two groups of a table, such as the verb endings.
+func newSuffixTries(groups [][]string) [2]*porter.Trie {
+	return [2]*porter.Trie{porter.NewTrie(groups[0]), porter.NewTrie(groups[1])}
+}
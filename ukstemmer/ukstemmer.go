@@ -0,0 +1,199 @@
+// Package ukstemmer implements Porter stemmer for Ukrainian language.
+//
+// The stemmer follows the structure of the Russian stemmer of the rustemmer package and shares
+// its region and suffix matching code, with the vowels and the suffix tables of Ukrainian.
+// Importing the package registers the stemmer in the rustemmer registry for the language "uk".
+package ukstemmer
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/liderman/rustemmer"
+	"github.com/liderman/rustemmer/internal/porter"
+)
+
+// VOWEL lists the Ukrainian vowels used to find the regions of a word.
+const VOWEL = "аеєиіїоуюя"
+
+// The suffix tables of the steps. The first group of the perfective gerund and verb endings
+// is removed only after "а" or "я".
+var suffixPerfectiveGerunds = [2]*porter.Trie{
+	porter.NewTrie([]string{"в", "вши", "вшись"}),
+	porter.NewTrie([]string{"ив", "ивши", "ившись"}),
+}
+var suffixReflexives = porter.NewTrie([]string{"ся", "сь", "си"})
+var suffixAdjective = porter.NewTrie([]string{
+	"ий", "ій", "ого", "ього", "ому", "ьому", "им", "ім", "их", "іх", "ими", "іми", "ою", "ьою", "ої", "ьої",
+})
+var suffixVerb = [2]*porter.Trie{
+	porter.NewTrie([]string{
+		"ти", "ть", "в", "ла", "ло", "ли", "ю", "єш", "є", "ємо", "єте", "ють", "й", "йте", "ймо",
+	}),
+	porter.NewTrie([]string{
+		"ити", "іти", "ути", "ить", "іть", "ать", "ять", "уть", "еш", "иш", "їш", "е", "емо", "имо", "ете", "ите",
+		"ив", "ила", "ило", "или", "іла", "іло", "іли", "ють",
+	}),
+}
+var suffixNoun = porter.NewTrie([]string{
+	"а", "я", "и", "і", "ї", "у", "ю", "о", "е", "є", "ь", "ів", "їв", "ей", "ам", "ям", "ами", "ями", "ах", "ях",
+	"ою", "ею", "єю", "ом", "ем", "єм", "ові", "еві", "єві", "ій", "ьми",
+})
+var suffixSuperlative = porter.NewTrie([]string{"іш", "ейш"})
+var suffixDerivational = porter.NewTrie([]string{"іст", "ість", "ост", "ость"})
+var suffixSoftSign = porter.NewTrie([]string{"ь"})
+var suffixI = porter.NewTrie([]string{"и"})
+var suffixNN = porter.NewTrie([]string{"нн"})
+
+// wordRegexp matches a single word of a text. Apostrophes inside a word, as in "м'ясо", are part of it.
+var wordRegexp = regexp.MustCompile("[\\p{L}\\p{M}\\d_]+(?:['’ʼ][\\p{L}\\p{M}]+)*")
+
+// pool is the pool of stemmers used by the package-level functions.
+var pool = sync.Pool{
+	New: func() interface{} {
+		return New()
+	},
+}
+
+// pooledStemmer is a rustemmer.WordStemmer that stems Ukrainian words with the stemmers of pool,
+// so it is safe for concurrent use.
+type pooledStemmer struct{}
+
+// Stem returns the base of the word.
+func (pooledStemmer) Stem(word string) string {
+	return GetWordBase(word)
+}
+
+func init() {
+	rustemmer.Register("uk", pooledStemmer{})
+}
+
+// UkStemmer is a stemmer for Ukrainian language.
+// An UkStemmer is not safe for concurrent use; use the package-level functions instead.
+type UkStemmer struct {
+	word []rune
+	RV int
+	R2 int
+}
+
+var _ rustemmer.Stemmer = (*UkStemmer)(nil)
+var _ rustemmer.WordStemmer = (*UkStemmer)(nil)
+
+// New creates a new UkStemmer.
+func New() *UkStemmer {
+	return &UkStemmer{}
+}
+
+// GetWordBase returns the base word.
+func GetWordBase(word string) string {
+	s := pool.Get().(*UkStemmer)
+	defer pool.Put(s)
+	return s.GetWordBase(word)
+}
+
+// NormalizeText returns text in which all words are replaced with their bases separated by a space.
+// All Special characters except "_" and apostrophes inside words will be removed.
+func NormalizeText(text string) string {
+	s := pool.Get().(*UkStemmer)
+	defer pool.Put(s)
+	return s.NormalizeText(text)
+}
+
+// GetWordBase returns the base word. As with the Russian stemmer, suffixes are matched in lower case,
+// so words are best converted to lower case before stemming, and only the trailing Cyrillic part
+// of the word is stemmed, so Latin words and numbers pass through as they are.
+func (s *UkStemmer) GetWordBase(word string) string {
+	head, tail := porter.SplitCyrillicTail(word)
+	if tail == "" {
+		return word
+	}
+
+	s.word = append(s.word[:0], []rune(tail)...)
+	s.stemWord()
+
+	return head + string(s.word)
+}
+
+// Stem returns the base word. It is the same as GetWordBase and implements rustemmer.WordStemmer.
+func (s *UkStemmer) Stem(word string) string {
+	return s.GetWordBase(word)
+}
+
+// NormalizeText returns text in which all words are replaced with their bases separated by a space.
+// All Special characters except "_" and apostrophes inside words will be removed.
+func (s *UkStemmer) NormalizeText(text string) string {
+	words := wordRegexp.FindAllString(text, -1)
+	for k, word := range words {
+		words[k] = s.GetWordBase(word)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// stemWord runs the Porter steps over s.word, leaving the base in it.
+func (s *UkStemmer) stemWord() {
+	// All steps work in RV except the derivational step, which works in R2.
+	s.RV, _, s.R2 = porter.Regions(s.word, VOWEL)
+
+	// Step 1
+	// Find ending PERFECTIVE GERUND. If it exists - delete it and complete this step
+	if !s.removeEndings(s.RV, suffixPerfectiveGerunds[0], suffixPerfectiveGerunds[1]) {
+		// Otherwise, remove ending REFLEXIVE (if it exists)
+		s.removeEndings(s.RV, suffixReflexives)
+		// Then try the following procedure to remove ending: ADJECTIVE, VERB, NOUN.
+		// As soon as one of them is found - a step ends
+		if !s.removeEndings(s.RV, suffixAdjective) && !s.removeEndings(s.RV, suffixVerb[0], suffixVerb[1]) {
+			s.removeEndings(s.RV, suffixNoun)
+		}
+	}
+
+	// Step 2
+	// If a word ends with "и" - remove the "и"
+	s.removeEndings(s.RV, suffixI)
+
+	// Step 3
+	// If in "R2" there DERIVATIONAL ending - delete it
+	s.removeEndings(s.R2, suffixDerivational)
+
+	// Step 4
+	// Remove the comparative suffix and then reduce "нн" to "н", or otherwise remove "ь"
+	if s.removeEndings(s.RV, suffixSuperlative) {
+		s.undoubleN()
+	} else if !s.undoubleN() {
+		s.removeEndings(s.RV, suffixSoftSign)
+	}
+}
+
+// undoubleN deletes the last letter if the word ends in "нн".
+func (s *UkStemmer) undoubleN() bool {
+	if !s.removeEndings(s.RV, suffixNN) {
+		return false
+	}
+	s.word = append(s.word, 'н')
+
+	return true
+}
+
+// removeEndings removes the longest suffix of the tables found in the region of the word.
+// With two tables, the suffixes of the first one must be preceded by "а" or "я".
+func (s *UkStemmer) removeEndings(region int, tables ...*porter.Trie) bool {
+	if region > len(s.word) {
+		region = len(s.word)
+	}
+
+	word := s.word[region:]
+	n := 0
+	if len(tables) == 2 {
+		n = tables[0].Match(word, "ая")
+	}
+	if m := tables[len(tables) - 1].Match(word, ""); m > n {
+		n = m
+	}
+	if n == 0 {
+		return false
+	}
+
+	s.word = s.word[:len(s.word) - n]
+	return true
+}
@@ -0,0 +1,75 @@
+package ukstemmer
+
+import (
+	"testing"
+
+	"github.com/liderman/rustemmer"
+)
+
+func TestGetWordBase(t *testing.T) {
+	testWords := map[string]string{
+		"вагони"      : "вагон",
+		"вагонів"     : "вагон",
+		"вагонами"    : "вагон",
+		"книжками"    : "книжк",
+		"читати"      : "чита",
+		"читала"      : "чита",
+		"читаю"       : "чита",
+		"прочитавши"  : "прочита",
+		"зробивши"    : "зроб",
+		"зробила"     : "зроб",
+		"працюють"    : "працю",
+		"сміявся"     : "смія",
+		"новими"      : "нов",
+		"нова"        : "нов",
+		"нові"        : "нов",
+		"новіший"     : "нов",
+		"синього"     : "син",
+		"красивого"   : "красив",
+		"мовою"       : "мов",
+		"вулицею"     : "вулиц",
+		"країні"      : "країн",
+		"можливість"  : "можлив",
+		"радість"     : "радіст",
+		"знання"      : "знан",
+		"м'ясо"       : "м'яс",
+		"стіл"        : "стіл",
+		"я"           : "я",
+		"Windows"     : "Windows",
+		"USB-мережі"  : "USB-мереж",
+		"👍вагони"     : "👍вагон",
+		"вагони2"     : "вагони2",
+		""            : "",
+	}
+
+	for word, base := range testWords {
+		if testBase := GetWordBase(word); testBase != base {
+			t.Errorf("Not equal: [%s] %s != %s", word, base, testBase)
+		}
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	text := "Нові вагони м'ясокомбінату, 25 шт."
+	if normalized := NormalizeText(text); normalized != "Нов вагон м'ясокомбінат 25 шт" {
+		t.Errorf("Not equal: %s != %s", "Нов вагон м'ясокомбінат 25 шт", normalized)
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	uk, ok := rustemmer.ForLanguage("uk")
+	if !ok {
+		t.Fatal("Expected a stemmer for uk")
+	}
+	if base := uk.Stem("вагони"); base != "вагон" {
+		t.Errorf("Not equal: вагон != %s", base)
+	}
+
+	normalized, err := rustemmer.NormalizeTextLang("Нові вагони", "uk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if normalized != "Нов вагон" {
+		t.Errorf("Not equal: Нов вагон != %s", normalized)
+	}
+}
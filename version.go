@@ -0,0 +1,222 @@
+package rustemmer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// AlgorithmVersion is the version of the stemming algorithm of this release. It is increased whenever
+// a change makes the stemmer return different bases for some words, so that search indexes built with
+// an older version can keep stemming their queries as before with StemV or WithAlgorithmVersion.
+//
+// The versions are:
+//
+//	1: the algorithm of the first release, which removes the first listed suffix that matches rather than
+//	   the longest one, ignores a leading vowel when finding the regions, undoubles "нн" before removing
+//	   the superlative and stems words however short their bases are
+//	2: the steps follow the Snowball specification, and words whose bases would be shorter than
+//	   DefaultMinStemLength are left unstemmed
+const AlgorithmVersion = 2
+
+// ErrUnknownVersion is returned by StemV for versions of the algorithm it does not know.
+var ErrUnknownVersion = errors.New("rustemmer: unknown algorithm version")
+
+// versionOptions maps the versions of the algorithm to the options reproducing them.
+var versionOptions = map[int][]Option{
+	1 : {withStepsVersion(1), WithMinStemLength(0)},
+	2 : {withStepsVersion(2), WithMinStemLength(DefaultMinStemLength)},
+}
+
+// versionPools holds a pool of stemmers for every version of the algorithm, used by StemV.
+var versionPools = newVersionPools()
+
+// newVersionPools returns the pools of stemmers of the versions of the algorithm.
+func newVersionPools() map[int]*sync.Pool {
+	pools := make(map[int]*sync.Pool, len(versionOptions))
+	for version := range versionOptions {
+		version := version
+		pools[version] = &sync.Pool{
+			New: func() interface{} {
+				return New(WithAlgorithmVersion(version))
+			},
+		}
+	}
+
+	return pools
+}
+
+// withStepsVersion sets the version of the algorithm whose Porter steps the stemmer runs.
+func withStepsVersion(version int) Option {
+	return func(r *RuStemmer) {
+		r.stepsVersion = version
+	}
+}
+
+// WithAlgorithmVersion makes the stemmer return the bases of the given version of the algorithm,
+// one from 1 to AlgorithmVersion. Options given after it override the corresponding settings.
+// It panics if the version is unknown.
+func WithAlgorithmVersion(version int) Option {
+	opts, ok := versionOptions[version]
+	if !ok {
+		panic(fmt.Sprintf("%s %d", ErrUnknownVersion, version))
+	}
+
+	return func(r *RuStemmer) {
+		for _, opt := range opts {
+			opt(r)
+		}
+	}
+}
+
+// StemV returns the base of the word as the given version of the algorithm finds it with the default options.
+// Pass the version stored with an index, initially AlgorithmVersion, so its bases do not change across releases.
+func StemV(version int, word string) (string, error) {
+	pool, ok := versionPools[version]
+	if !ok {
+		return "", fmt.Errorf("%w %d", ErrUnknownVersion, version)
+	}
+
+	r := pool.Get().(*RuStemmer)
+	defer pool.Put(r)
+	return r.GetWordBase(word), nil
+}
+
+// The suffix tables of version 1 of the algorithm, in the order they are matched in.
+var suffixPerfectiveGerundsV1 = [][]string{
+	{"в", "вши", "вшись", "в", "вши", "вшись"},
+	{"ив", "ивши", "ившись", "ыв", "ывши", "ывшись"},
+}
+var suffixAdjectiveV1 = []string{
+	"ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом", "его", "ого", "ему",
+	"ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею",
+}
+var suffixVerbV1 = [][]string{
+	{"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно"},
+	{
+		"ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым", "ен",
+		"ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю",
+	},
+}
+var suffixNounV1 = []string{
+	"а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой", "ий", "й", "иям",
+	"ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю", "ия", "ья", "я",
+}
+var suffixParticipleV1 = [][]string{
+	appendPrefix(suffixAdjectiveV1, []string{"ем", "нн", "вш", "ющ", "щ"}),
+	appendPrefix(suffixAdjectiveV1, []string{"ивш", "ывш", "ующ"}),
+}
+
+// porterStepsV1 runs the steps of version 1 of the Porter algorithm over r.word.
+// It must not be changed, so that StemV(1, word) keeps returning the bases it returned in the first release.
+func (r *RuStemmer) porterStepsV1() {
+	r.RV, r.R1, r.R2 = findRegionsV1(r.word)
+
+	// Step 1
+	if !r.applyStepV1(StepPerfectiveGerund, r.RV, suffixPerfectiveGerundsV1[0], suffixPerfectiveGerundsV1[1]) {
+		r.applyStepV1(StepReflexive, r.RV, []string{"ся", "сь"})
+		ife := r.applyStepV1(
+			StepAdjectival,
+			r.RV,
+			suffixParticipleV1[0],
+			suffixParticipleV1[1],
+		) || r.applyStepV1(StepAdjectival, r.RV, suffixAdjectiveV1)
+
+		if !ife && !r.applyStepV1(StepVerb, r.RV, suffixVerbV1[0], suffixVerbV1[1]) {
+			r.applyStepV1(StepNoun, r.RV, suffixNounV1)
+		}
+	}
+
+	// Step 2
+	r.applyStepV1(StepI, r.RV, []string{"и"})
+
+	// Step 3
+	r.applyStepV1(StepDerivational, r.R2, []string{"ост", "ость"})
+
+	// Step 4
+	// Unlike in Snowball, "нн" is undoubled before the superlative is removed, and all three variants are tried
+	if r.removeSuffixV1(r.RV, []string{"нн"}) != "" {
+		r.word = append(r.word, 'н')
+		r.recordStep(StepNN)
+		r.traceStep(StepNN, "н")
+	}
+	r.applyStepV1(StepSuperlative, r.RV, []string{"ейш", "ейше"})
+	r.applyStepV1(StepSoftSign, r.RV, []string{"ь"})
+}
+
+// applyStepV1 removes a suffix like removeSuffixV1 and records the step like applyStep.
+func (r *RuStemmer) applyStepV1(step string, region int, suffixesPacks ...[]string) bool {
+	suffix := r.removeSuffixV1(region, suffixesPacks...)
+	if suffix == "" {
+		return false
+	}
+	r.recordStep(step)
+	r.traceStep(step, suffix)
+
+	return true
+}
+
+// removeSuffixV1 removes the first suffix of the tables that lies in the region starting at the rune offset
+// and returns it, or an empty string if there is none. If there are two tables, the suffixes of the first one
+// must be preceded by "а" or "я", and they are tried before those of the second one.
+func (r *RuStemmer) removeSuffixV1(region int, suffixesPacks ...[]string) string {
+	if region > len(r.word) {
+		region = len(r.word)
+	}
+
+	word := string(r.word[region:])
+	suffix := ""
+	if len(suffixesPacks) == 2 {
+		suffix = firstSuffixV1(word, suffixesPacks[0], true)
+	}
+	if suffix == "" {
+		suffix = firstSuffixV1(word, suffixesPacks[len(suffixesPacks) - 1], false)
+	}
+	r.word = r.word[:len(r.word) - utf8.RuneCountInString(suffix)]
+
+	return suffix
+}
+
+// firstSuffixV1 returns the first of the suffixes the word ends in, if isAYA preceded by "а" or "я",
+// or an empty string if there is none.
+func firstSuffixV1(word string, suffixes []string, isAYA bool) string {
+	for _, suffix := range suffixes {
+		if isAYA && !(strings.HasSuffix(word, "а" + suffix) || strings.HasSuffix(word, "я" + suffix)) {
+			continue
+		}
+		if strings.HasSuffix(word, suffix) {
+			return suffix
+		}
+	}
+
+	return ""
+}
+
+// findRegionsV1 returns the RV, R1 and R2 regions of the word as version 1 of the algorithm finds them:
+// the first letter is never taken for a vowel, and R2 is left at 0 if the word has no R2.
+func findRegionsV1(word []rune) (rv, r1, r2 int) {
+	state := 0
+	for i := 1; i < len(word); i++ {
+		isVowel, wasVowel := strings.ContainsRune(VOWEL, word[i]), strings.ContainsRune(VOWEL, word[i - 1])
+		switch state {
+		case 0:
+			if isVowel {
+				rv = i + 1
+				state = 1
+			}
+		case 1:
+			if wasVowel && !isVowel {
+				r1 = i + 1
+				state = 2
+			}
+		case 2:
+			if wasVowel && !isVowel {
+				return rv, r1, i + 1
+			}
+		}
+	}
+
+	return rv, r1, r2
+}
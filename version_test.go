@@ -0,0 +1,90 @@
+package rustemmer
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestStemV(t *testing.T) {
+	testWords := []struct {
+		version  int
+		word     string
+		expected string
+	}{
+		{1, "ей", ""},
+		{1, "вагоны", "вагон"},
+		{1, "ценнейший", "ценн"},
+		{1, "длиннейшая", "длинн"},
+		{1, "умнейшего", "умнейш"},
+		{2, "ей", "ей"},
+		{2, "вагоны", "вагон"},
+		{2, "ценнейший", "цен"},
+		{AlgorithmVersion, "ей", GetWordBase("ей")},
+	}
+
+	for _, test := range testWords {
+		base, err := StemV(test.version, test.word)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if base != test.expected {
+			t.Errorf("Not equal: [%d %s] %s != %s", test.version, test.word, test.expected, base)
+		}
+	}
+
+	for _, version := range []int{0, AlgorithmVersion + 1} {
+		if _, err := StemV(version, "вагоны"); !errors.Is(err, ErrUnknownVersion) {
+			t.Errorf("Expected ErrUnknownVersion for %d, got %v", version, err)
+		}
+	}
+}
+
+func TestWithAlgorithmVersion(t *testing.T) {
+	if base := New(WithAlgorithmVersion(1)).GetWordBase("мою"); base != "мо" {
+		t.Errorf("Not equal: мо != %s", base)
+	}
+	if base := New(WithAlgorithmVersion(1), WithMinStemLength(2)).GetWordBase("ей"); base != "ей" {
+		t.Errorf("Not equal: ей != %s", base)
+	}
+	if base := New(WithAlgorithmVersion(1), WithAlgorithmVersion(2)).GetWordBase("ценнейший"); base != "цен" {
+		t.Errorf("Not equal: цен != %s", base)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an unknown version")
+		}
+	}()
+	WithAlgorithmVersion(AlgorithmVersion + 1)
+}
+
+func TestStemVGolden(t *testing.T) {
+	// testdata/version1.tsv holds the words of testdata/stems.txt and testdata/snowball/voc.txt
+	// with the bases GetWordBase returned for them in the first release, separated by a tab.
+	file, err := os.Open("testdata/version1.tsv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word, expected, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok {
+			t.Fatalf("Malformed line: %q", scanner.Text())
+		}
+		base, err := StemV(1, word)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if base != expected {
+			t.Errorf("Not equal: [%s] %s != %s", word, expected, base)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+}